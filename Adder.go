@@ -3,8 +3,50 @@ package lambda
 import (
 	"fmt"
 	"reflect"
+	"sync"
 )
 
+// Divider is implemented by the Add of a registered type to support Average,
+// Quo returns the sum divided by n
+type Divider interface {
+	Quo(n int) interface{}
+}
+
+var adders = struct {
+	sync.RWMutex
+	m map[reflect.Type]func() Add
+}{m: map[reflect.Type]func() Add{}}
+
+// RegisterAdder makes the values of type t summable by Sum and Average,
+// factory returns a new Add of t, the Add implements Divider to support Average.
+// a registered type is preferred to the builtin Adder, factory nil removes the registration.
+// it is safe for concurrent use
+func RegisterAdder(t reflect.Type, factory func() Add) {
+	adders.Lock()
+	defer adders.Unlock()
+	if factory == nil {
+		delete(adders.m, t)
+		return
+	}
+	adders.m[t] = factory
+}
+
+// factory of the registered type t, nil when t is not registered
+func registered(t reflect.Type) func() Add {
+	adders.RLock()
+	defer adders.RUnlock()
+	return adders.m[t]
+}
+
+// Add of the registered type t, nil when t is not registered
+func registeredAdder(t reflect.Type) Add {
+	if factory := registered(t); factory != nil {
+		return factory()
+	}
+	return nil
+}
+
+// sum of integers of t, the sum wraps around on overflow
 type _int struct {
 	v interface{}
 	t reflect.Type
@@ -15,28 +57,10 @@ func (i _int) Value() interface{} {
 }
 
 func (i *_int) SetZero() {
-	switch i.t.Kind() {
-	case reflect.Int:
-		i.v = 0
-	case reflect.Uint8:
-		i.v = uint8(0)
-	case reflect.Uint16:
-		i.v = uint16(0)
-	case reflect.Uint32:
-		i.v = uint32(0)
-	case reflect.Uint64:
-		i.v = uint64(0)
-	case reflect.Int8:
-		i.v = int8(0)
-	case reflect.Int16:
-		i.v = int16(0)
-	case reflect.Int32:
-		i.v = int32(0)
-	case reflect.Int64:
-		i.v = int64(0)
-	}
+	i.v = reflect.Zero(i.t).Interface()
 }
 
+// sum of floats of t
 type _float struct {
 	v interface{}
 	t reflect.Type
@@ -47,41 +71,30 @@ func (f _float) Value() interface{} {
 }
 
 func (f *_float) SetZero() {
-	switch f.t.Kind() {
-	case reflect.Float32:
-		f.v = float32(0)
-	case reflect.Float64:
-		f.v = float64(0)
-	}
+	f.v = reflect.Zero(f.t).Interface()
 }
 
 func Adder(t reflect.Type) Add {
-
-	support := []reflect.Kind{
-		reflect.Int,
-		reflect.Uint8,
-		reflect.Uint16,
-		reflect.Uint32,
-		reflect.Uint64,
-		reflect.Int8,
-		reflect.Int16,
-		reflect.Uint32,
-		reflect.Int64,
-		reflect.Float32,
-		reflect.Float64,
+	if add := registeredAdder(t); add != nil {
+		add.SetZero()
+		return add
 	}
-	vk := t.Kind()
-	if contain(support, vk) {
-		var add Add
-		if vk == reflect.Float64 || vk == reflect.Float32 {
-			add = &_float{t: t}
-		} else {
-			add = &_int{t: t}
-		}
+	if add := bigAdder(t); add != nil {
 		add.SetZero()
 		return add
 	}
-	panic("not support type " + t.String())
+
+	var add Add
+	switch {
+	case isInt(t), isUint(t):
+		add = &_int{t: t}
+	case isFloat(t):
+		add = &_float{t: t}
+	default:
+		panic("not support type " + t.String())
+	}
+	add.SetZero()
+	return add
 }
 
 type Add interface {
@@ -94,59 +107,21 @@ func (i *_int) Add(value reflect.Value) {
 	if value.Kind() != i.t.Kind() {
 		panic(fmt.Sprintf("not support %s add %s", i.t.String(), value.Type().String()))
 	}
-	switch value.Type().Kind() {
-	case reflect.Int:
-		sum := i.v.(int)
-		sum += value.Interface().(int)
-		i.v = sum
-	case reflect.Uint8:
-		sum := i.v.(uint8)
-		sum += value.Interface().(uint8)
-		i.v = sum
-	case reflect.Uint16:
-		sum := i.v.(uint16)
-		sum += value.Interface().(uint16)
-		i.v = sum
-	case reflect.Uint32:
-		sum := i.v.(uint32)
-		sum += value.Interface().(uint32)
-		i.v = sum
-	case reflect.Uint64:
-		sum := i.v.(uint64)
-		sum += value.Interface().(uint64)
-		i.v = sum
-	case reflect.Int8:
-		sum := i.v.(int8)
-		sum += value.Interface().(int8)
-		i.v = sum
-	case reflect.Int16:
-		sum := i.v.(int16)
-		sum += value.Interface().(int16)
-		i.v = sum
-	case reflect.Int32:
-		sum := i.v.(int32)
-		sum += value.Interface().(int32)
-		i.v = sum
-	case reflect.Int64:
-		sum := i.v.(int64)
-		sum += value.Interface().(int64)
-		i.v = sum
+	// SetInt and SetUint truncate to the size of t, so the sum wraps around like the + of t
+	sum := reflect.New(i.t).Elem()
+	if isUint(i.t) {
+		sum.SetUint(reflect.ValueOf(i.v).Uint() + value.Uint())
+	} else {
+		sum.SetInt(reflect.ValueOf(i.v).Int() + value.Int())
 	}
+	i.v = sum.Interface()
 }
 
 func (f *_float) Add(value reflect.Value) {
 	if value.Kind() != f.t.Kind() {
 		panic(fmt.Sprintf("not support %s add %s", f.t.String(), value.Type().String()))
 	}
-
-	switch value.Type().Kind() {
-	case reflect.Float32:
-		sum := f.v.(float32)
-		sum += value.Interface().(float32)
-		f.v = sum
-	case reflect.Float64:
-		sum := f.v.(float64)
-		sum += value.Interface().(float64)
-		f.v = sum
-	}
+	sum := reflect.New(f.t).Elem()
+	sum.SetFloat(reflect.ValueOf(f.v).Float() + value.Float())
+	f.v = sum.Interface()
 }
@@ -0,0 +1,142 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type money struct {
+	currency string
+	cents    int64
+}
+
+type vector struct {
+	x, y float64
+}
+
+type _money struct {
+	v money
+}
+
+func (m *_money) Add(value reflect.Value) {
+	a := value.Interface().(money)
+	if m.v.currency == "" {
+		m.v.currency = a.currency
+	} else if a.currency != m.v.currency {
+		panic("currency " + a.currency + " is not " + m.v.currency)
+	}
+	m.v.cents += a.cents
+}
+func (m *_money) Value() interface{}    { return m.v }
+func (m *_money) SetZero()              { m.v = money{} }
+func (m *_money) Quo(n int) interface{} { return money{m.v.currency, m.v.cents / int64(n)} }
+
+type _duration struct {
+	v time.Duration
+}
+
+func (d *_duration) Add(value reflect.Value) { d.v += value.Interface().(time.Duration) }
+func (d *_duration) Value() interface{}      { return d.v }
+func (d *_duration) SetZero()                { d.v = 0 }
+func (d *_duration) Quo(n int) interface{}   { return d.v / time.Duration(n) }
+
+type _vector struct {
+	v vector
+}
+
+func (a *_vector) Add(value reflect.Value) {
+	b := value.Interface().(vector)
+	a.v.x += b.x
+	a.v.y += b.y
+}
+func (a *_vector) Value() interface{}    { return a.v }
+func (a *_vector) SetZero()              { a.v = vector{} }
+func (a *_vector) Quo(n int) interface{} { return vector{a.v.x / float64(n), a.v.y / float64(n)} }
+
+type _complex struct {
+	v complex128
+}
+
+func (c *_complex) Add(value reflect.Value) { c.v += value.Complex() }
+func (c *_complex) Value() interface{}      { return c.v }
+func (c *_complex) SetZero()                { c.v = 0 }
+
+func TestRegisterAdder(t *testing.T) {
+	defer report(t, time.Now())
+	RegisterAdder(reflect.TypeOf(money{}), func() Add { return &_money{} })
+	RegisterAdder(reflect.TypeOf(time.Duration(0)), func() Add { return &_duration{} })
+	RegisterAdder(reflect.TypeOf(vector{}), func() Add { return &_vector{} })
+	RegisterAdder(reflect.TypeOf(complex128(0)), func() Add { return &_complex{} })
+	defer func() {
+		for _, v := range []interface{}{money{}, time.Duration(0), vector{}, complex128(0)} {
+			RegisterAdder(reflect.TypeOf(v), nil)
+		}
+	}()
+
+	prices := LambdaArray([]money{{"EUR", 1999}, {"EUR", 1}, {"EUR", 3000}})
+	isTrue(t, prices.Sum(nil) == money{"EUR", 5000})
+	isTrue(t, prices.AverageValue(nil) == money{"EUR", 1666})
+	isTrue(t, LambdaArray([]money{}).AverageValue(nil) == money{})
+	isTrue(t, prices.SumWith(nil, SumOptions{Overflow: Checked}) == money{"EUR", 5000})
+
+	durations := LambdaArray([]time.Duration{time.Second, 2 * time.Second})
+	isTrue(t, durations.Sum(nil) == 3*time.Second)
+	isTrue(t, durations.AverageValue(nil) == 1500*time.Millisecond)
+	isTrue(t, durations.Average(nil) == float64(1500*time.Millisecond))
+	isTrue(t, fmt.Sprint(durations.RunningSum(nil).Pointer()) == "[1s 3s]")
+
+	vectors := LambdaArray([]vector{{1, 2}, {3, 4}})
+	isTrue(t, vectors.Sum(nil) == vector{4, 6})
+	isTrue(t, vectors.AverageValue(nil) == vector{2, 3})
+
+	complexes := LambdaArray([]complex128{1 + 2i, 3 - 1i})
+	isTrue(t, complexes.Sum(nil) == 4+1i)
+	isTrue(t, complexes.AsParallel(2).Sum(nil) == 4+1i)
+
+	// not a Divider, Average panics
+	_, err := TryOf(complexes).Average(nil)
+	isTrue(t, err != nil)
+	// not a number
+	_, err = TryOf(vectors).Average(nil)
+	isTrue(t, err != nil)
+	isTrue(t, LambdaArray([]int{1, 2}).AverageValue(nil) == 1.5)
+}
+
+func TestRegisterAdder_Concurrent(t *testing.T) {
+	defer report(t, time.Now())
+	defer RegisterAdder(reflect.TypeOf(vector{}), nil)
+	vectors := LambdaArray([]vector{{1, 2}, {3, 4}})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterAdder(reflect.TypeOf(vector{}), func() Add { return &_vector{} })
+		}()
+		go func() {
+			defer wg.Done()
+			// registered by another goroutine or not yet
+			_, _ = TryOf(vectors).Sum(nil)
+		}()
+	}
+	wg.Wait()
+	isTrue(t, vectors.Sum(nil) == vector{4, 6})
+}
+
+func TestRegisterAdder_Factory(t *testing.T) {
+	defer report(t, time.Now())
+	defer RegisterAdder(reflect.TypeOf(vector{}), nil)
+	calls := 0
+	RegisterAdder(reflect.TypeOf(vector{}), func() Add {
+		calls++
+		return &_vector{}
+	})
+	vectors := LambdaArray([]vector{{1, 2}, {3, 4}})
+	isTrue(t, vectors.SumWith(nil, SumOptions{Overflow: Checked}) == vector{4, 6})
+	isTrue(t, calls == 1)
+	isTrue(t, vectors.Sum(nil) == vector{4, 6})
+	isTrue(t, calls == 2)
+}
@@ -0,0 +1,132 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var anyType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+func (p *_array) Aggregate(seed, express, resultSelector interface{}) interface{} {
+	var acc reflect.Value
+	argument(2, func() { acc = p.checkFold(seed, express) })
+	fn := reflect.ValueOf(express)
+	p.EachV(func(v reflect.Value, _ int) {
+		acc = fn.Call([]reflect.Value{acc, v})[0]
+	})
+	if resultSelector == nil {
+		return acc.Interface()
+	}
+	argument(3, func() { checkExpressRARTO(resultSelector, []reflect.Type{acc.Type()}) })
+	return reflect.ValueOf(resultSelector).Call([]reflect.Value{acc})[0].Interface()
+}
+
+// check express func(acc A, ele T) A of Aggregate and Scan, returns the seed, the zero value of A when seed is nil
+func (p *_array) checkFold(seed, express interface{}) reflect.Value {
+	ft := reflect.TypeOf(express)
+	if ft == nil || ft.Kind() != reflect.Func || ft.NumIn() == 0 {
+		checkExpress(ft, []reflect.Type{anyType, p.elementType}, []reflect.Type{anyType})
+	}
+	acc := reflect.Zero(ft.In(0))
+	if seed != nil {
+		acc = reflect.ValueOf(seed)
+	}
+	in, out := []reflect.Type{acc.Type(), p.elementType}, []reflect.Type{acc.Type()}
+	checkExpress(ft, in, out)
+	checkAssignable(ft, in, out)
+	return acc
+}
+
+// the params of exp accept the values of in and its results are assignable to out,
+// checkExpress compares the kinds only, so a seed of type MyInt passes it for func(acc int, ...)
+func checkAssignable(exp reflect.Type, in []reflect.Type, out []reflect.Type) {
+	for i, t := range in {
+		if !t.AssignableTo(exp.In(i)) {
+			panic(&ExpressError{Express: exp, Want: signature(in, out),
+				Reason: fmt.Sprintf("lambda express the %d'th parameter Type must be %s,not %s", i, t.String(), exp.In(i).String())})
+		}
+	}
+	for i, t := range out {
+		if !exp.Out(i).AssignableTo(t) {
+			panic(&ExpressError{Express: exp, Want: signature(in, out),
+				Reason: fmt.Sprintf("lambda express the %d'th return Type must be %s,not %s", i, t.String(), exp.Out(i).String())})
+		}
+	}
+}
+
+func (p *_array) Reduce(express interface{}) (interface{}, error) {
+	express = bindExpress2(express, p.elementType)
+	in, out := []reflect.Type{p.elementType, p.elementType}, []reflect.Type{p.elementType}
+	checkExpress(reflect.TypeOf(express), in, out)
+	checkAssignable(reflect.TypeOf(express), in, out)
+	if p.Len() == 0 {
+		return nil, errors.New("empty array")
+	}
+	fn := reflect.ValueOf(express)
+	acc := p.value.Index(0)
+	for i := 1; i < p.Len(); i++ {
+		acc = fn.Call([]reflect.Value{acc, p.value.Index(i)})[0]
+	}
+	return acc.Interface(), nil
+}
+
+func (p *_array) Scan(seed, express interface{}) Array {
+	var acc reflect.Value
+	argument(2, func() { acc = p.checkFold(seed, express) })
+	fn := reflect.ValueOf(express)
+	ret := reflect.MakeSlice(reflect.SliceOf(acc.Type()), p.Len(), p.Len())
+	p.EachV(func(v reflect.Value, i int) {
+		acc = fn.Call([]reflect.Value{acc, v})[0]
+		ret.Index(i).Set(acc)
+	})
+	return innerLambdaArray(ret)
+}
+
+// values returned by express, the elements when express is nil
+func (p *_array) selectValues(express interface{}) Array {
+	if express == nil {
+		return p
+	}
+	return p.Map(express)
+}
+
+func (p *_array) RunningSum(express interface{}) Array {
+	values := asInner(p.selectValues(express))
+	add := Adder(values.elementType)
+	ret := reflect.MakeSlice(reflect.SliceOf(values.elementType), values.Len(), values.Len())
+	values.EachV(func(v reflect.Value, i int) {
+		add.Add(v)
+		ret.Index(i).Set(reflect.ValueOf(add.Value()))
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) runningMaxOrMin(express interface{}, isMax bool) Array {
+	values := asInner(p.selectValues(express))
+	ret := reflect.MakeSlice(reflect.SliceOf(values.elementType), values.Len(), values.Len())
+	var m reflect.Value
+	values.EachV(func(v reflect.Value, i int) {
+		if i == 0 {
+			m = v
+		} else {
+			tor, err := BasicComparator(v.Interface())
+			if err != nil {
+				panic(err)
+			}
+			if c := tor.CompareTo(m.Interface()); (isMax && c > 0) || (!isMax && c < 0) {
+				m = v
+			}
+		}
+		ret.Index(i).Set(m)
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) RunningMax(express interface{}) Array {
+	return p.runningMaxOrMin(express, true)
+}
+
+func (p *_array) RunningMin(express interface{}) Array {
+	return p.runningMaxOrMin(express, false)
+}
@@ -1,8 +1,11 @@
 package lambda
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math/big"
 	"reflect"
 	"strings"
 )
@@ -36,11 +39,21 @@ type Array interface {
 
 	// sort by quick
 	// eg
+	// express nil sorts ascending by BasicComparator, a struct by its lambda tags
 	Sort(express interface{}) Array
 
 	// sort by quick multithreading
+	// express nil and Field sort ascending like Sort
 	SortMT(express interface{}) Array
 
+	// stable ascending sort by key, further keys can be chained by ThenBy/ThenByDescending
+	// express func(ele T) K, K must be number Type, string or Compare
+	// eg: arr.OrderBy(func(u user) int { return u.age }).ThenBy(func(u user) string { return u.name })
+	OrderBy(express interface{}) OrderedArray
+
+	// stable descending sort by key
+	OrderByDescending(express interface{}) OrderedArray
+
 	// map to new array
 	// express func(el T) T{ return T }
 	Map(express interface{}) Array
@@ -50,10 +63,12 @@ type Array interface {
 
 	// maximum of array
 	// express eg: express func(ele TIn) TOut{ return TOut },TOut must be number Type or Compare
+	// express nil compares the elements, a struct by its lambda tags
 	Max(express interface{}) interface{}
 
 	// minimum of array
 	// express eg: express func(ele TIn) TOut{ return TOut },TOut must be number Type or Compare
+	// express nil compares the elements, a struct by its lambda tags
 	Min(express interface{}) interface{}
 
 	// Determines whether the Array contains any elements
@@ -78,11 +93,118 @@ type Array interface {
 	Take(skip, count int) Array
 
 	// sum of the values returned by the expression
+	// the values are number Type, *big.Int, *big.Float, *big.Rat or a type registered by RegisterAdder
 	Sum(express interface{}) interface{}
 
 	// average of the values returned by the expression
 	Average(express interface{}) float64
 
+	// average in the type returned by Divider.Quo when the Add of the values is a Divider,
+	// eg: time.Duration, complex128 or a vector registered by RegisterAdder
+	// the other values are averaged by Average
+	AverageValue(express interface{}) interface{}
+
+	// Sum with the overflow policy of options
+	SumWith(express interface{}, options SumOptions) interface{}
+
+	// Average with the overflow policy of options
+	AverageWith(express interface{}, options SumOptions) float64
+
+	// exact sum of the values returned by the expression, express nil sums the elements
+	// the values are number Type, *big.Int, *big.Float or *big.Rat,
+	// floats are taken as the shortest decimal that reads back to them, so 0.1 is 1/10
+	SumDecimal(express interface{}) *big.Rat
+
+	// exact average of the values returned by the expression, see SumDecimal
+	AverageDecimal(express interface{}) *big.Rat
+
+	// median of the values returned by the expression, same as Percentile(express, 50)
+	Median(express interface{}) float64
+
+	// percentile of the values returned by the expression, percent in [0, 100]
+	// interpolated linearly between the closest ranks
+	Percentile(express interface{}, percent float64) float64
+
+	// the n-1 cut points dividing the values returned by the expression into n intervals of equal probability
+	Quantiles(express interface{}, n int) []float64
+
+	// population variance of the values returned by the expression
+	Variance(express interface{}) float64
+
+	// sample variance of the values returned by the expression, divided by n-1
+	SampleVariance(express interface{}) float64
+
+	// population standard deviation of the values returned by the expression
+	StdDev(express interface{}) float64
+
+	// sample standard deviation of the values returned by the expression
+	SampleStdDev(express interface{}) float64
+
+	// the most frequent value returned by the expression, the first one on equal counts
+	// express nil counts the elements, nil when the array is empty
+	Mode(express interface{}) interface{}
+
+	// fold the elements into an accumulator starting with seed
+	// express func(acc A, ele T) A, seed nil starts with the zero value of A
+	// resultSelector func(acc A) R transforms the final accumulator, nil returns the accumulator
+	// eg: arr.Aggregate(0, func(acc int, u user) int { return acc + u.age }, nil)
+	Aggregate(seed, express, resultSelector interface{}) interface{}
+
+	// fold the elements with the first element as seed, error when the array is empty
+	// express func(a, b T) T or a lambda string like "(a, b) => a + b"
+	Reduce(express interface{}) (interface{}, error)
+
+	// every intermediate accumulator of Aggregate, returns Array of A
+	// express func(acc A, ele T) A, seed nil starts with the zero value of A
+	Scan(seed, express interface{}) Array
+
+	// cumulative sums of the values returned by the expression
+	// express nil sums the elements
+	RunningSum(express interface{}) Array
+
+	// running maximum of the values returned by the expression
+	// express nil compares the elements, values must be number Type, string or Compare
+	RunningMax(express interface{}) Array
+
+	// running minimum of the values returned by the expression
+	RunningMin(express interface{}) Array
+
+	// pair the elements at the same index, stops at the shorter array
+	// express func(a T, b U) R
+	Zip(other Array, express interface{}) Array
+
+	// pair the elements at the same index, the shorter array is padded with defaultA or defaultB
+	// nil default pads with the zero value
+	ZipLongest(other Array, express, defaultA, defaultB interface{}) Array
+
+	// split into Arrays of size elements, the last one may be shorter, returns Array of Array
+	// the chunks share the elements with the array without copy
+	Chunk(size int) Array
+
+	// overlapping windows of size elements every step elements, returns Array of Array
+	// only full windows are returned, they share the elements with the array without copy
+	Window(size, step int) Array
+
+	// aggregate every window by express, eg: rolling average
+	// express func(window Array) R
+	WindowMap(size, step int, express interface{}) Array
+
+	// sums of the values returned by the expression over every window, see Window
+	// integers are summed by a running sum adding the values entering and subtracting the values leaving the window,
+	// floats are summed window by window, so an Inf or NaN stays in its windows
+	// express nil sums the elements, values must be number Type or registered by RegisterAdder
+	WindowSum(size, step int, express interface{}) Array
+
+	// averages of the values returned by the expression over every window, see WindowSum, returns Array of float64
+	WindowAverage(size, step int, express interface{}) Array
+
+	// maximums of the values returned by the expression over every window by a monotonic deque
+	// express nil compares the elements, values must be number Type, string or Compare
+	WindowMax(size, step int, express interface{}) Array
+
+	// minimums of the values returned by the expression over every window
+	WindowMin(size, step int, express interface{}) Array
+
 	// Determines whether the array contains the specified element
 	// number type use default comparator
 	// other type can implements Compare
@@ -91,6 +213,64 @@ type Array interface {
 	// array or slice pointer
 	// Array.Pointer().([]T or [n]T)
 	Pointer() interface{}
+
+	// group elements by key in first-seen order, returns Array of Group
+	// express func(ele T) K, K is comparable or implements Compare or Equal, keys are matched like DistinctBy
+	// eg: arr.GroupBy(func(u user) int { return u.age / 10 }).Pointer().([]Group)
+	GroupBy(express interface{}) Array
+
+	// one-to-many index from key to elements
+	// express func(ele T) K, keys are matched like GroupBy
+	ToLookup(express interface{}) Lookup
+
+	// inner join with other by key, the elements without matches are dropped
+	// outerKey func(ele T) K, innerKey func(ele U) K, resultSelector func(o T, i U) R
+	// keys implement Compare or Equal are matched like Contains, the others are hashed
+	InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) Array
+
+	// left outer join, resultSelector is called with defaultInner when an outer element has no match
+	// defaultInner nil uses the zero value of U
+	LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) Array
+
+	// correlate every outer element with the Array of its inner matches
+	// resultSelector func(o T, inners Array) R
+	GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) Array
+
+	// remove duplicate elements, keeps the first occurrence
+	// elements implement Compare or Equal are compared like Contains,
+	// structs with lambda tags by their key fields, the others are hashed
+	Distinct() Array
+
+	// remove elements with duplicate key, keeps the first occurrence
+	// express func(ele T) K
+	DistinctBy(express interface{}) Array
+
+	// distinct elements of the array and other
+	Union(other Array) Array
+
+	// distinct elements of the array which are in other
+	Intersect(other Array) Array
+
+	// distinct elements of the array which are not in other
+	Except(other Array) Array
+
+	// execute Filter, Map, Count, Any, Sum, Max and Min by a fixed pool of workers
+	// workers <= 0 uses runtime.NumCPU()
+	AsParallel(workers int) ParallelArray
+
+	// run the operators under ctx, they stop and return ctx.Err() when ctx is done
+	WithContext(ctx context.Context) ContextArray
+
+	// lazy form of the array, operators are executed on demand
+	// eg: arr.AsEnumerable().Map(...).Filter(...).First(nil)
+	AsEnumerable() Enumerable
+
+	// write the elements to w as CSV, the header row of the column names first
+	// the elements are structs or pointers to struct, see StreamCSV
+	WriteCSV(w io.Writer) error
+
+	// write the elements to w as JSON Lines, one JSON value per line
+	WriteJSONLines(w io.Writer) error
 }
 
 func innerLambdaArray(value reflect.Value) Array {
@@ -112,71 +292,98 @@ type _array struct {
 }
 
 func (p *_array) Contains(express interface{}) bool {
+	match := matcher(express, p.elementType)
 	sz := p.Len()
+	for i := 0; i < sz; i++ {
+		if match(p.value.Index(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// make the element matcher of Contains
+// express is a func(ele T) bool, a struct equal by its lambda tags,
+// a number/string compared by BasicComparator or an Equal
+func matcher(express interface{}, elementType reflect.Type) func(v reflect.Value) bool {
+	if _, ok := express.(string); !ok {
+		// a plain string is an element, Lambda is the express
+		express = bindExpress(express, elementType)
+	}
 	if express == nil {
 		panic("express is null")
 	}
+	_, isCompare := express.(Compare)
+	_, isEqual := express.(Equal)
 	if t := reflect.TypeOf(express); t.Kind() == reflect.Func {
-		expType := reflect.TypeOf(express)
-		checkExpress(expType, []reflect.Type{p.elementType}, []reflect.Type{reflect.TypeOf(true)})
+		checkExpress(t, []reflect.Type{elementType}, []reflect.Type{reflect.TypeOf(true)})
 		fn := reflect.ValueOf(express)
-		for i := 0; i < sz; i++ {
-			ret := fn.Call([]reflect.Value{p.value.Index(i)})
-			if ret[0].Interface().(bool) {
-				return true
-			}
+		return func(v reflect.Value) bool {
+			return fn.Call([]reflect.Value{v})[0].Interface().(bool)
+		}
+	} else if tc, err := TaggedComparator(express); err == nil && !isCompare && !isEqual {
+		return func(v reflect.Value) bool {
+			return tc.Equals(v.Interface())
 		}
 	} else if tor, err := BasicComparator(express); err == nil {
-		for i := 0; i < sz; i++ {
-			if tor.CompareTo(p.value.Index(i).Interface()) == 0 {
-				return true
-			}
+		return func(v reflect.Value) bool {
+			return tor.CompareTo(v.Interface()) == 0
 		}
 	} else if eq, ok := express.(Equal); ok {
-		for i := 0; i < sz; i++ {
-			if eq.Equals(p.value.Index(i).Interface()) {
-				return true
-			}
+		return func(v reflect.Value) bool {
+			return eq.Equals(v.Interface())
 		}
 	} else {
 		panic("unknown type " + t.String())
 	}
-	return false
 }
 
 func (p *_array) Average(express interface{}) float64 {
+	return p.AverageWith(express, SumOptions{})
+}
+
+func (p *_array) AverageWith(express interface{}, options SumOptions) float64 {
 	length := p.Len()
 	if length == 0 {
 		return float64(0)
 	}
-	sum := p.Sum(express)
+	add := p.accumulate(express, options)
+	if d, ok := add.(Divider); ok {
+		q := reflect.ValueOf(d.Quo(length))
+		if isNumber(q.Type()) {
+			return q.Convert(reflect.TypeOf(float64(0))).Float()
+		}
+		panic(fmt.Sprintf("average %s is not a number, use AverageValue", q.Type().String()))
+	}
+	sum := add.Value()
 
 	switch sum.(type) {
-	case int:
-		return float64(sum.(int)) / float64(length)
-	case uint8:
-		return float64(sum.(uint8)) / float64(length)
-	case uint16:
-		return float64(sum.(uint16)) / float64(length)
-	case uint32:
-		return float64(sum.(uint32)) / float64(length)
-	case uint64:
-		return float64(sum.(uint64)) / float64(length)
-	case int8:
-		return float64(sum.(int8)) / float64(length)
-	case int16:
-		return float64(sum.(int16)) / float64(length)
-	case int32:
-		return float64(sum.(int32)) / float64(length)
-	case int64:
-		return float64(sum.(int64)) / float64(length)
-	case float32:
-		return float64(sum.(float32)) / float64(length)
-	case float64:
-		return sum.(float64) / float64(length)
-	default:
-		panic("unknown type " + reflect.TypeOf(sum).String())
+	case *big.Int:
+		f, _ := new(big.Rat).SetFrac(sum.(*big.Int), big.NewInt(int64(length))).Float64()
+		return f
+	case *big.Float:
+		f, _ := new(big.Float).Quo(sum.(*big.Float), big.NewFloat(float64(length))).Float64()
+		return f
+	case *big.Rat:
+		f, _ := new(big.Rat).Quo(sum.(*big.Rat), new(big.Rat).SetInt64(int64(length))).Float64()
+		return f
 	}
+	if v := reflect.ValueOf(sum); isNumber(v.Type()) {
+		return v.Convert(reflect.TypeOf(float64(0))).Float() / float64(length)
+	}
+	panic("unknown type " + reflect.TypeOf(sum).String())
+}
+
+func (p *_array) AverageValue(express interface{}) interface{} {
+	add := p.accumulate(express, SumOptions{})
+	d, ok := add.(Divider)
+	if !ok {
+		return p.Average(express)
+	}
+	if length := p.Len(); length > 0 {
+		return d.Quo(length)
+	}
+	return add.Value()
 }
 
 func (p *_array) Append(elements ...interface{}) Array {
@@ -191,6 +398,7 @@ func (p *_array) Append(elements ...interface{}) Array {
 }
 
 func (p *_array) Any(express interface{}) bool {
+	express = bindExpress(express, p.elementType)
 	if express == nil {
 		return p.Len() > 0
 	}
@@ -210,6 +418,7 @@ func (p *_array) Any(express interface{}) bool {
 }
 
 func (p *_array) All(express interface{}) bool {
+	express = bindExpress(express, p.elementType)
 	if express == nil {
 		return p.Len() > 0
 	}
@@ -232,6 +441,7 @@ func (p *_array) All(express interface{}) bool {
 }
 
 func (p *_array) Count(express interface{}) int {
+	express = bindExpress(express, p.elementType)
 	if express == nil {
 		return p.Len()
 	}
@@ -250,6 +460,7 @@ func (p *_array) Count(express interface{}) int {
 }
 
 func (p *_array) Find(express interface{}, start, step int) (interface{}, error) {
+	express = bindExpress(express, p.elementType)
 	length := p.Len()
 	if length == 0 {
 		return nil, errors.New("empty array")
@@ -303,21 +514,31 @@ func (p *_array) Take(skip, count int) Array {
 }
 
 func (p *_array) Sum(express interface{}) interface{} {
+	return p.SumWith(express, SumOptions{})
+}
+
+func (p *_array) SumWith(express interface{}, options SumOptions) interface{} {
+	return p.accumulate(express, options).Value()
+}
+
+// Add of the values returned by the expression
+func (p *_array) accumulate(express interface{}, options SumOptions) Add {
+	express = bindExpress(express, p.elementType)
 
 	var add Add
 	if express == nil {
-		add = Adder(p.elementType)
+		add = options.adder(p.elementType)
 	} else {
 		checkExpress(
 			reflect.TypeOf(express),
 			[]reflect.Type{p.elementType},
 			nil)
-		add = Adder(reflect.TypeOf(express).Out(0))
+		add = options.adder(reflect.TypeOf(express).Out(0))
 	}
 
 	length := p.Len()
 	if length == 0 {
-		return add.Value()
+		return add
 	}
 
 	fn := reflect.ValueOf(express)
@@ -333,7 +554,7 @@ func (p *_array) Sum(express interface{}) interface{} {
 	for i := 0; i < length; i++ {
 		add.Add(fv(i))
 	}
-	return add.Value()
+	return add
 }
 
 func (p *_array) Pointer() interface{} {
@@ -352,20 +573,27 @@ func (p *_array) Len() int {
 // exp the express function type
 // in express function parameter types
 // out express function return types
+// panic *ExpressError when exp does not match
 func checkExpress(exp reflect.Type, in []reflect.Type, out []reflect.Type) {
+	fail := func(reason string) {
+		panic(&ExpressError{Express: exp, Want: signature(in, out), Reason: reason})
+	}
+	if exp == nil {
+		fail("express is null")
+	}
 	if exp.Kind() != reflect.Func {
-		panic("express is not a func express")
+		fail("express is not a func express")
 	}
 	// check in
 	numIn := exp.NumIn()
 	lenIn := len(in)
 	if numIn != lenIn {
-		panic(fmt.Errorf("lambda express parameter count must be %d", lenIn))
+		fail(fmt.Sprintf("lambda express parameter count must be %d", lenIn))
 	}
 	for i := 0; i < lenIn; i++ {
 		if in[i].Kind() != exp.In(i).Kind() {
-			panic(fmt.Errorf("lambda express the %d'th parameter Type must be %s,not %s,func=%s",
-				i, exp.In(i).String(), in[i].String(), exp.String()))
+			fail(fmt.Sprintf("lambda express the %d'th parameter Type must be %s,not %s,func=%s",
+				i, in[i].String(), exp.In(i).String(), exp.String()))
 		}
 	}
 	if out == nil {
@@ -375,11 +603,11 @@ func checkExpress(exp reflect.Type, in []reflect.Type, out []reflect.Type) {
 	numOut := exp.NumOut()
 	lenOut := len(out)
 	if numOut != lenOut {
-		panic(fmt.Errorf("lambda express return Types count must be %d", lenOut))
+		fail(fmt.Sprintf("lambda express return Types count must be %d", lenOut))
 	}
 	for i := 0; i < lenOut; i++ {
 		if out[i].Kind() != exp.Out(i).Kind() {
-			panic(fmt.Errorf("lambda express the %d'th return Type must be %s", i, exp.Out(i).String()))
+			fail(fmt.Sprintf("lambda express the %d'th return Type must be %s", i, out[i].String()))
 		}
 	}
 }
@@ -387,8 +615,12 @@ func checkExpress(exp reflect.Type, in []reflect.Type, out []reflect.Type) {
 // check the function express
 func checkExpressRARTO(express interface{}, in []reflect.Type) reflect.Type {
 	t := reflect.TypeOf(express)
+	if t == nil || t.Kind() != reflect.Func {
+		checkExpress(t, in, nil)
+	}
 	if t.NumOut() == 0 {
-		panic("lambda express must has only one return-value.")
+		panic(&ExpressError{Express: t, Want: signature(in, nil),
+			Reason: "lambda express must has only one return-value."})
 	}
 	ot := t.Out(0)
 	checkExpress(t, in, []reflect.Type{ot})
@@ -396,6 +628,7 @@ func checkExpressRARTO(express interface{}, in []reflect.Type) reflect.Type {
 }
 
 func (p *_array) Map(express interface{}) Array {
+	express = bindExpress(express, p.elementType)
 	in := []reflect.Type{p.elementType}
 	ot := checkExpressRARTO(express, in)
 
@@ -452,6 +685,7 @@ func (p *_array) Join(option JoinOptions) string {
 }
 
 func (p *_array) Filter(express interface{}) Array {
+	express = bindExpress(express, p.elementType)
 	in := []reflect.Type{p.elementType}
 	ft := reflect.TypeOf(express)
 	ot := reflect.TypeOf(true)
@@ -472,6 +706,7 @@ func (p *_array) Filter(express interface{}) Array {
 }
 
 func (p *_array) SortByBubble(express interface{}) Array {
+	express = bindLess(express, p.elementType)
 	in := []reflect.Type{p.elementType, p.elementType}
 	ft := reflect.TypeOf(express)
 	ot := reflect.TypeOf(true)
@@ -508,7 +743,18 @@ func (p *_array) CopyValue() reflect.Value {
 	return arr
 }
 
+// the array as slice, array type is copied into a new slice
+func (p *_array) slice() *_array {
+	if p.arrayType.Kind() == reflect.Slice {
+		return p
+	}
+	ret := reflect.MakeSlice(reflect.SliceOf(p.elementType), p.Len(), p.Len())
+	reflect.Copy(ret, p.value)
+	return innerLambdaArray(ret).(*_array)
+}
+
 func (p *_array) Sort(express interface{}) Array {
+	express = bindLess(express, p.elementType)
 	in := []reflect.Type{p.elementType, p.elementType}
 	ft := reflect.TypeOf(express)
 	ot := reflect.TypeOf(true)
@@ -558,6 +804,21 @@ func (p *_array) Sort(express interface{}) Array {
 }
 
 func (p *_array) SortMT(express interface{}) Array {
+	ret, _ := p.sortMT(context.Background(), express)
+	return ret
+}
+
+// sort by quick multithreading, every partition is sorted by a goroutine
+// all goroutines stop and ctx.Err() is returned when ctx is done
+func (p *_array) sortMT(ctx context.Context, express interface{}) (Array, error) {
+	// the elements for which express(pivot, ele) is true are placed before the pivot,
+	// so the ascending order of nil and of a lesser is by greater
+	if l, ok := express.(lesser); ok {
+		express = swap(l.less(p.elementType))
+	} else if express == nil && orderedField(p.elementType) {
+		express = natural(p.elementType, 1)
+	}
+	express = bindLess(express, p.elementType)
 	in := []reflect.Type{p.elementType, p.elementType}
 	ft := reflect.TypeOf(express)
 	ot := reflect.TypeOf(true)
@@ -568,15 +829,22 @@ func (p *_array) SortMT(express interface{}) Array {
 	compare := func(a, b reflect.Value) bool {
 		return funcValue.Call([]reflect.Value{a, b})[0].Interface().(bool)
 	}
+	send := func(ch chan reflect.Value, v reflect.Value) bool {
+		select {
+		case ch <- v:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
 	var quick func(arr reflect.Value, ch chan reflect.Value)
 	quick = func(arr reflect.Value, ch chan reflect.Value) {
+		defer close(ch)
 		if arr.Len() == 1 {
-			ch <- arr.Index(0)
-			close(ch)
+			send(ch, arr.Index(0))
 			return
 		}
 		if arr.Len() == 0 {
-			close(ch)
 			return
 		}
 
@@ -585,6 +853,9 @@ func (p *_array) SortMT(express interface{}) Array {
 		length := arr.Len()
 		x := arr.Index(0)
 		for i := 1; i < length; i++ {
+			if ctx.Err() != nil {
+				return
+			}
 			curr := arr.Index(i)
 			if compare(x, curr) {
 				left = reflect.Append(left, curr)
@@ -592,18 +863,24 @@ func (p *_array) SortMT(express interface{}) Array {
 				right = reflect.Append(right, curr)
 			}
 		}
+		// buffered, so the children never block when the parent stops reading
 		lch := make(chan reflect.Value, left.Len())
 		rch := make(chan reflect.Value, right.Len())
 		go quick(left, lch)
 		go quick(right, rch)
 		for v := range lch {
-			ch <- v
+			if !send(ch, v) {
+				return
+			}
+		}
+		if !send(ch, x) {
+			return
 		}
-		ch <- x
 		for v := range rch {
-			ch <- v
+			if !send(ch, v) {
+				return
+			}
 		}
-		close(ch)
 	}
 	ch := make(chan reflect.Value)
 	go quick(p.value, ch)
@@ -611,15 +888,16 @@ func (p *_array) SortMT(express interface{}) Array {
 	for v := range ch {
 		values = reflect.Append(values, v)
 	}
-	return innerLambdaArray(values)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return innerLambdaArray(values), nil
 }
 
 func (p *_array) maxOrMin(express interface{}, isMax bool) interface{} {
+	express = bindExpress(express, p.elementType)
 	if express != nil {
-		in := []reflect.Type{p.elementType}
-		ft := reflect.TypeOf(express)
-		ot := reflect.TypeOf(0)
-		checkExpress(ft, in, []reflect.Type{ot})
+		checkExpressRARTO(express, []reflect.Type{p.elementType})
 	}
 	var m reflect.Value
 	var mc interface{}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -36,6 +37,17 @@ func makeUserArray() []user {
 	return want
 }
 
+// the users most tests query, a new slice on every call
+func makeUsers() []user {
+	return []user{
+		{"Abraham", 20},
+		{"Edith", 25},
+		{"Charles", 40},
+		{"Anthony", 26},
+		{"Abel", 33},
+	}
+}
+
 func report(t *testing.T, start time.Time) {
 	end := time.Now()
 	ms := float32(end.Nanosecond()-start.Nanosecond()) / float32(1e6)
@@ -93,13 +105,7 @@ func Test__array_Filter(t *testing.T) {
 	ret2 := larr.Filter(func(ele int) bool { return ele%2 == 0 }).Pointer().([]int)
 	fmt.Println(ret2)
 
-	users := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	users := makeUsers()
 	ret3 := LambdaArray(users).Filter(func(u user) bool { return u.age < 30 }).Pointer().([]user)
 	fmt.Println(ret3)
 }
@@ -126,13 +132,7 @@ func Test__array_Sort_Quick(t *testing.T) {
 	fmt.Println(ret1)
 	fmt.Println(ret2)
 
-	users := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	users := makeUsers()
 	ret3 := LambdaArray(users).Sort(func(a, b user) bool { return a.age < b.age }).Pointer().([]user)
 	fmt.Println(ret3)
 }
@@ -206,13 +206,7 @@ func Test__array_Max(t *testing.T) {
 	ret3 := wantUsers.Max(nil)
 	t.Log(ret3)
 
-	users := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	users := makeUsers()
 	eldest := LambdaArray(users).Max(func(u user) int { return u.age }).(user)
 	fmt.Println(eldest.name + " is the eldest")
 }
@@ -241,13 +235,7 @@ func Test__array_Sort_Min(t *testing.T) {
 	ret3 := wantUsers.Min(nil)
 	t.Log(ret3)
 
-	users := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	users := makeUsers()
 	eldest := LambdaArray(users).Min(func(u user) int { return u.age }).(user)
 	fmt.Println(eldest.name + " is the Charles")
 }
@@ -265,13 +253,7 @@ func Test__array_Any(t *testing.T) {
 	isFalse(t, ret[1])
 	isTrue(t, ret[2])
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	ret1 := LambdaArray(us).Any(func(u user) bool { return u.age > 30 })
 	fmt.Println(ret1)
 	ret2 := LambdaArray(us).Any(func(u user) bool { return u.age < 0 })
@@ -291,13 +273,7 @@ func Test__array_All(t *testing.T) {
 	isTrue(t, ret[1])
 	isFalse(t, ret[2])
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	ret1 := LambdaArray(us).All(func(u user) bool { return u.age > 30 })
 	fmt.Println(ret1)
 	ret2 := LambdaArray(us).All(func(u user) bool { return u.age > 10 })
@@ -314,13 +290,7 @@ func Test__array_Count(t *testing.T) {
 	isTrue(t, ret[0] == count)
 	isTrue(t, ret[1]*2 == count)
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	ret1 := LambdaArray(us).Count(func(u user) bool { return u.age > 30 })
 	fmt.Println(ret1)
 	ret2 := LambdaArray(us).Count(func(u user) bool { return u.age > 20 })
@@ -337,13 +307,7 @@ func Test__array_First(t *testing.T) {
 		t.Fail()
 	}
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	arr := LambdaArray(us)
 	if u, err := arr.First(func(u user) bool { return u.name == "Charles" }); err == nil {
 		fmt.Println(u, " found")
@@ -390,13 +354,7 @@ func Test__array_Last(t *testing.T) {
 		t.Fail()
 	}
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	arr := LambdaArray(us)
 	if u, err := arr.Last(func(u user) bool { return u.name == "Anthony" }); err == nil {
 		fmt.Println(u, " found")
@@ -430,13 +388,7 @@ func Test__array_Sum(t *testing.T) {
 	ret2 := LambdaArray(makeUserArray()).Sum(func(u user) int { return u.age })
 	t.Log(ret, ret2)
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	arr := LambdaArray(us)
 	fmt.Println("total user age is", arr.Sum(func(u user) int { return u.age }))
 }
@@ -447,13 +399,7 @@ func Test__array_Avg(t *testing.T) {
 	ret := ints.Average(nil)
 	t.Log(ret)
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	arr := LambdaArray(us)
 	fmt.Println("all user average age is", arr.Average(func(u user) int { return u.age }))
 }
@@ -483,13 +429,7 @@ func Test__array_Contain(t *testing.T) {
 	isTrue(t, ret[0])
 	isFalse(t, ret[1])
 
-	us := []user{
-		{"Abraham", 20},
-		{"Edith", 25},
-		{"Charles", 40},
-		{"Anthony", 26},
-		{"Abel", 33},
-	}
+	us := makeUsers()
 	arr2 := LambdaArray(us)
 	fmt.Println(arr2.Contains(func(u user) bool { return u.age > 25 }))
 
@@ -503,3 +443,279 @@ func (u user) Equals(obj interface{}) bool {
 	}
 	return false
 }
+
+func Test__array_GroupBy(t *testing.T) {
+	defer report(t, time.Now())
+	groups := LambdaArray(makeUserArray()).GroupBy(func(u user) int { return u.age % 10 }).Pointer().([]Group)
+	isTrue(t, len(groups) == 10)
+	isTrue(t, groups[0].Key == 1 && groups[0].Count(nil) == count/10)
+
+	us := makeUsers()
+	byInitial := LambdaArray(us).GroupBy(func(u user) byte { return u.name[0] }).Pointer().([]Group)
+	for _, g := range byInitial {
+		fmt.Println(string(g.Key.(byte)), g.Count(nil), g.Sum(func(u user) int { return u.age }))
+	}
+	isTrue(t, len(byInitial) == 3)
+	isTrue(t, byInitial[0].Sum(func(u user) int { return u.age }) == 79)
+	isTrue(t, byInitial[2].Max(func(u user) int { return u.age }).(user).name == "Charles")
+}
+
+func Test__array_ToLookup(t *testing.T) {
+	defer report(t, time.Now())
+	us := makeUsers()
+	lookup := LambdaArray(us).ToLookup(func(u user) bool { return u.age > 25 })
+	isTrue(t, lookup.Len() == 2)
+	isTrue(t, lookup.Get(true).Count(nil) == 3)
+	isTrue(t, lookup.Get(false).Average(func(u user) int { return u.age }) == 22.5)
+	isFalse(t, lookup.Contains("jack"))
+	isTrue(t, lookup.Get("jack").Count(nil) == 0)
+	isTrue(t, fmt.Sprint(lookup.Keys().Pointer()) == "[false true]")
+
+	// Compare keys are matched by CompareTo like DistinctBy, account compares the ages only
+	byAccount := func(u user) account { return account{u.name, u.age / 10} }
+	groups := LambdaArray(us).GroupBy(byAccount).Pointer().([]Group)
+	isTrue(t, len(groups) == LambdaArray(us).DistinctBy(byAccount).Count(nil))
+	isTrue(t, len(groups) == 3 && groups[0].Count(nil) == 3 && groups[0].Key.(account).name == "Abraham")
+	accounts := LambdaArray(us).ToLookup(byAccount)
+	isTrue(t, accounts.Get(account{"any", 2}).Count(nil) == 3)
+	isTrue(t, accounts.Contains(account{"", 4}) && !accounts.Contains(account{"Edith", 5}))
+}
+
+type order struct {
+	owner string
+	total int
+}
+
+func makeOrders() []order {
+	return []order{
+		{"Edith", 100},
+		{"Abel", 30},
+		{"Edith", 50},
+		{"jack", 10},
+	}
+}
+
+func Test__array_InnerJoin(t *testing.T) {
+	defer report(t, time.Now())
+	us := makeUsers()
+	ret := LambdaArray(us).InnerJoin(LambdaArray(makeOrders()),
+		func(u user) string { return u.name },
+		func(o order) string { return o.owner },
+		func(u user, o order) string { return fmt.Sprintf("%s:%d", u.name, o.total) }).Pointer().([]string)
+	fmt.Println(ret)
+	isTrue(t, fmt.Sprint(ret) == "[Edith:100 Edith:50 Abel:30]")
+
+	// keys implement Equal
+	accounts := []account{{"Edith", 25}, {"Abel", 33}}
+	ret2 := LambdaArray(us).InnerJoin(LambdaArray(accounts),
+		func(u user) user { return u },
+		func(a account) user { return user(a) },
+		func(u user, a account) string { return a.name }).Pointer().([]string)
+	isTrue(t, fmt.Sprint(ret2) == "[Edith Abel]")
+}
+
+func Test__array_LeftJoin(t *testing.T) {
+	defer report(t, time.Now())
+	us := []user{
+		{"Abraham", 20},
+		{"Edith", 25},
+		{"Abel", 33},
+	}
+	ret := LambdaArray(us).LeftJoin(LambdaArray(makeOrders()),
+		func(u user) string { return u.name },
+		func(o order) string { return o.owner },
+		func(u user, o order) int { return o.total },
+		order{"", -1}).Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret) == "[-1 100 50 30]")
+
+	ret2 := LambdaArray(us).LeftJoin(LambdaArray(makeOrders()),
+		func(u user) string { return u.name },
+		func(o order) string { return o.owner },
+		func(u user, o order) int { return o.total },
+		nil).Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret2) == "[0 100 50 30]")
+
+	// a concrete default of an interface element type
+	events := []interface{}{"Edith", "Nobody"}
+	ret3 := LambdaArray(us).LeftJoin(LambdaArray(events),
+		func(u user) string { return u.name },
+		func(e interface{}) string { return e.(string) },
+		func(u user, e interface{}) string { return fmt.Sprint(e) },
+		"none").Pointer().([]string)
+	isTrue(t, fmt.Sprint(ret3) == "[none Edith none]")
+}
+
+func Test__array_GroupJoin(t *testing.T) {
+	defer report(t, time.Now())
+	us := []user{
+		{"Abraham", 20},
+		{"Edith", 25},
+		{"Abel", 33},
+	}
+	ret := LambdaArray(us).GroupJoin(LambdaArray(makeOrders()),
+		func(u user) string { return u.name },
+		func(o order) string { return o.owner },
+		func(u user, orders Array) int { return orders.Sum(func(o order) int { return o.total }).(int) }).Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret) == "[0 150 30]")
+}
+
+func Test__array_Distinct(t *testing.T) {
+	defer report(t, time.Now())
+	ret := LambdaArray([]int{3, 1, 3, 2, 1, 5}).Distinct().Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret) == "[3 1 2 5]")
+
+	big := append(makeIntArray(), makeIntArray()...)
+	isTrue(t, LambdaArray(big).Distinct().Count(nil) == count)
+
+	us := []user{
+		{"Abraham", 20},
+		{"Edith", 25},
+		{"Abraham", 20},
+		{"Anthony", 25},
+	}
+	isTrue(t, LambdaArray(us).Distinct().Count(nil) == 3)
+	byAge := LambdaArray(us).DistinctBy(func(u user) int { return u.age }).Pointer().([]user)
+	isTrue(t, fmt.Sprint(byAge) == "[{Abraham 20} {Edith 25}]")
+}
+
+func Test__array_Union(t *testing.T) {
+	defer report(t, time.Now())
+	a := LambdaArray([]int{1, 2, 3, 4})
+	b := LambdaArray([]int{3, 4, 5, 3})
+	isTrue(t, fmt.Sprint(a.Union(b).Pointer()) == "[1 2 3 4 5]")
+	isTrue(t, fmt.Sprint(a.Intersect(b).Pointer()) == "[3 4]")
+	isTrue(t, fmt.Sprint(a.Except(b).Pointer()) == "[1 2]")
+
+	us := LambdaArray([]user{{"Abraham", 20}, {"Edith", 25}})
+	others := LambdaArray([]user{{"Edith", 25}, {"Abel", 33}})
+	isTrue(t, us.Union(others).Count(nil) == 3)
+	isTrue(t, fmt.Sprint(us.Intersect(others).Pointer()) == "[{Edith 25}]")
+	isTrue(t, fmt.Sprint(us.Except(others).Pointer()) == "[{Abraham 20}]")
+}
+
+type employee struct {
+	department string
+	salary     int
+	name       string
+}
+
+func Test__array_OrderBy(t *testing.T) {
+	defer report(t, time.Now())
+	es := []employee{
+		{"sales", 100, "Edith"},
+		{"dev", 200, "Abel"},
+		{"sales", 300, "Charles"},
+		{"dev", 200, "Abraham"},
+		{"dev", 300, "Anthony"},
+		{"sales", 100, "Alice"},
+	}
+	ret := LambdaArray(es).
+		OrderBy(func(e employee) string { return e.department }).
+		ThenByDescending(func(e employee) int { return e.salary }).
+		Map(func(e employee) string { return e.name }).Pointer().([]string)
+	fmt.Println(ret)
+	// stable, Abel before Abraham and Edith before Alice
+	isTrue(t, fmt.Sprint(ret) == "[Anthony Abel Abraham Charles Edith Alice]")
+
+	ret2 := LambdaArray(es).
+		OrderBy(func(e employee) string { return e.department }).
+		ThenByDescending(func(e employee) int { return e.salary }).
+		ThenBy(func(e employee) string { return e.name }).
+		Map(func(e employee) string { return e.name }).Pointer().([]string)
+	isTrue(t, fmt.Sprint(ret2) == "[Anthony Abel Abraham Charles Alice Edith]")
+
+	// every key is evaluated once per element, ThenBy does not evaluate the keys before again
+	calls := 0
+	byDepartment := LambdaArray(es).OrderBy(func(e employee) string {
+		calls++
+		return e.department
+	})
+	bySalary := byDepartment.ThenBy(func(e employee) int { return e.salary })
+	byName := byDepartment.ThenByDescending(func(e employee) string { return e.name })
+	isTrue(t, calls == len(es))
+	isTrue(t, fmt.Sprint(bySalary.Map(Field("name")).Pointer()) == "[Abel Abraham Anthony Edith Alice Charles]")
+	isTrue(t, fmt.Sprint(byName.Map(Field("name")).Pointer()) == "[Anthony Abraham Abel Edith Charles Alice]")
+
+	ints := LambdaArray([]int{1, 3, 8, 6, 12, 5, 9}).OrderByDescending(func(e int) int { return e }).Pointer()
+	isTrue(t, fmt.Sprint(ints) == "[12 9 8 6 5 3 1]")
+
+	accounts := LambdaArray([]int{3, 1, 2}).Map(func(e int) account { return account{"zzz", e} })
+	min := accounts.OrderBy(func(a account) account { return a }).Pointer().([]account)[0]
+	isTrue(t, min.age == 1)
+}
+
+func Test__array_Aggregate(t *testing.T) {
+	defer report(t, time.Now())
+	us := makeUsers()
+	arr := LambdaArray(us)
+	total := arr.Aggregate(0, func(acc int, u user) int { return acc + u.age }, nil)
+	isTrue(t, total == 144)
+
+	names := arr.Aggregate(nil, func(acc []string, u user) []string { return append(acc, u.name) },
+		func(acc []string) int { return len(acc) })
+	isTrue(t, names == 5)
+
+	var buf strings.Builder
+	ret := arr.Aggregate(&buf, func(acc *strings.Builder, u user) *strings.Builder {
+		acc.WriteString(u.name[:1])
+		return acc
+	}, func(acc *strings.Builder) string { return acc.String() })
+	isTrue(t, ret == "AECAA")
+}
+
+func Test__array_Reduce(t *testing.T) {
+	defer report(t, time.Now())
+	ret, err := LambdaArray(makeIntArray()).Reduce(func(a, b int) int { return a + b })
+	isTrue(t, err == nil && ret == count*(count+1)/2)
+
+	eldest, err := LambdaArray(makeUserArray()).Reduce(func(a, b user) user {
+		if b.age > a.age {
+			return b
+		}
+		return a
+	})
+	isTrue(t, err == nil && eldest.(user).age == count)
+
+	_, err = LambdaArray([]int{}).Reduce(func(a, b int) int { return a + b })
+	isTrue(t, err != nil)
+
+	// a string is compiled with both elements
+	ret, err = LambdaArray([]int{1, 2, 3}).Reduce("(a, b) => a * b + 1")
+	isTrue(t, err == nil && ret == 10)
+
+	// the types are checked exactly, not by kind
+	type myInt int
+	isTrue(t, expressPanic(func() { LambdaArray([]int{1}).Reduce(func(a, b myInt) myInt { return a + b }) }))
+	isTrue(t, expressPanic(func() { LambdaArray([]int{1}).Aggregate(myInt(0), func(acc int, e int) int { return acc + e }, nil) }))
+	isTrue(t, expressPanic(func() { LambdaArray([]int{1}).Scan(nil, func(acc myInt, e int) int { return int(acc) + e }) }))
+	isTrue(t, LambdaArray([]int{1, 2}).Aggregate(myInt(1), func(acc myInt, e int) myInt { return acc + myInt(e) }, nil) == myInt(4))
+}
+
+// fn panics with *ExpressError
+func expressPanic(fn func()) (ok bool) {
+	defer func() {
+		_, ok = recover().(*ExpressError)
+	}()
+	fn()
+	return false
+}
+
+func Test__array_Scan(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{3, 1, 4, 1, 5})
+	ret := arr.Scan(10, func(acc int, e int) int { return acc + e }).Pointer()
+	isTrue(t, fmt.Sprint(ret) == "[13 14 18 19 24]")
+
+	path := arr.Scan(nil, func(acc string, e int) string { return acc + strconv.Itoa(e) }).Pointer()
+	isTrue(t, fmt.Sprint(path) == "[3 31 314 3141 31415]")
+
+	isTrue(t, fmt.Sprint(arr.RunningSum(nil).Pointer()) == "[3 4 8 9 14]")
+	isTrue(t, fmt.Sprint(arr.RunningMax(nil).Pointer()) == "[3 3 4 4 5]")
+	isTrue(t, fmt.Sprint(arr.RunningMin(nil).Pointer()) == "[3 1 1 1 1]")
+
+	us := makeUsers()[:3]
+	ages := LambdaArray(us).RunningSum(func(u user) float64 { return float64(u.age) / 2 }).Pointer()
+	isTrue(t, fmt.Sprint(ages) == "[10 22.5 42.5]")
+	names := LambdaArray(us).RunningMin(func(u user) string { return u.name }).Pointer()
+	isTrue(t, fmt.Sprint(names) == "[Abraham Abraham Abraham]")
+}
@@ -0,0 +1,153 @@
+package lambda
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+var (
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+	bigFloatType = reflect.TypeOf((*big.Float)(nil))
+	bigRatType   = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// adder of *big.Int, *big.Float and *big.Rat, nil for other types
+// the added values are not modified, nil pointers are added as zero
+func bigAdder(t reflect.Type) Add {
+	switch t {
+	case bigIntType:
+		return &_bigInt{}
+	case bigFloatType:
+		return &_bigFloat{}
+	case bigRatType:
+		return &_bigRat{}
+	}
+	return nil
+}
+
+type _bigInt struct {
+	v big.Int
+}
+
+func (b *_bigInt) Add(value reflect.Value) {
+	if value.Type() != bigIntType {
+		panic(fmt.Sprintf("not support %s add %s", bigIntType.String(), value.Type().String()))
+	}
+	if x := value.Interface().(*big.Int); x != nil {
+		b.v.Add(&b.v, x)
+	}
+}
+
+func (b *_bigInt) Value() interface{} {
+	return new(big.Int).Set(&b.v)
+}
+
+func (b *_bigInt) SetZero() {
+	b.v.SetInt64(0)
+}
+
+type _bigFloat struct {
+	v big.Float
+}
+
+func (b *_bigFloat) Add(value reflect.Value) {
+	if value.Type() != bigFloatType {
+		panic(fmt.Sprintf("not support %s add %s", bigFloatType.String(), value.Type().String()))
+	}
+	if x := value.Interface().(*big.Float); x != nil {
+		// the sum keeps the largest precision of the added values
+		if x.Prec() > b.v.Prec() {
+			b.v.SetPrec(x.Prec())
+		}
+		b.v.Add(&b.v, x)
+	}
+}
+
+func (b *_bigFloat) Value() interface{} {
+	return new(big.Float).Copy(&b.v)
+}
+
+func (b *_bigFloat) SetZero() {
+	// zero precision, it is set by the first added value
+	b.v = big.Float{}
+}
+
+type _bigRat struct {
+	v big.Rat
+}
+
+func (b *_bigRat) Add(value reflect.Value) {
+	if value.Type() != bigRatType {
+		panic(fmt.Sprintf("not support %s add %s", bigRatType.String(), value.Type().String()))
+	}
+	if x := value.Interface().(*big.Rat); x != nil {
+		b.v.Add(&b.v, x)
+	}
+}
+
+func (b *_bigRat) Value() interface{} {
+	return new(big.Rat).Set(&b.v)
+}
+
+func (b *_bigRat) SetZero() {
+	b.v.SetInt64(0)
+}
+
+// exact value of a number as *big.Rat
+// floats are taken as the shortest decimal that reads back to them, so 0.1 is 1/10
+func decimal(v reflect.Value) *big.Rat {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			panic(fmt.Sprintf("%v has no decimal value", f))
+		}
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, v.Type().Bits()))
+		return r
+	}
+	switch x := v.Interface().(type) {
+	case *big.Int:
+		if x == nil {
+			return new(big.Rat)
+		}
+		return new(big.Rat).SetInt(x)
+	case *big.Float:
+		if x == nil {
+			return new(big.Rat)
+		}
+		if x.IsInf() {
+			panic(fmt.Sprintf("%v has no decimal value", x))
+		}
+		r, _ := new(big.Rat).SetString(x.Text('g', -1))
+		return r
+	case *big.Rat:
+		if x == nil {
+			return new(big.Rat)
+		}
+		return new(big.Rat).Set(x)
+	}
+	panic("unknown type " + v.Type().String())
+}
+
+func (p *_array) SumDecimal(express interface{}) *big.Rat {
+	add := &_bigRat{}
+	asInner(p.selectValues(express)).EachV(func(v reflect.Value, _ int) {
+		add.Add(reflect.ValueOf(decimal(v)))
+	})
+	return add.Value().(*big.Rat)
+}
+
+func (p *_array) AverageDecimal(express interface{}) *big.Rat {
+	sum := p.SumDecimal(express)
+	if length := p.Len(); length > 0 {
+		sum.Quo(sum, new(big.Rat).SetInt64(int64(length)))
+	}
+	return sum
+}
@@ -0,0 +1,58 @@
+package lambda
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+type invoice struct {
+	no     string
+	amount *big.Rat
+	price  float64
+}
+
+func TestBig_Sum(t *testing.T) {
+	defer report(t, time.Now())
+	ints := LambdaArray([]*big.Int{big.NewInt(1), nil, new(big.Int).Lsh(big.NewInt(1), 100)})
+	sum := ints.Sum(nil).(*big.Int)
+	isTrue(t, sum.String() == "1267650600228229401496703205377")
+	// the elements are not modified
+	isTrue(t, ints.Pointer().([]*big.Int)[0].Int64() == 1)
+
+	invoices := []invoice{
+		{"A1", big.NewRat(1999, 100), 19.99},
+		{"A2", big.NewRat(1, 10), 0.1},
+		{"A3", big.NewRat(2, 10), 0.2},
+	}
+	amount := LambdaArray(invoices).Sum(func(i invoice) *big.Rat { return i.amount }).(*big.Rat)
+	isTrue(t, amount.FloatString(2) == "20.29")
+	isTrue(t, LambdaArray([]*big.Rat{}).Sum(nil).(*big.Rat).Sign() == 0)
+
+	floats := LambdaArray([]*big.Float{big.NewFloat(1.5), big.NewFloat(2.5)})
+	isTrue(t, floats.Sum(nil).(*big.Float).String() == "4")
+	isTrue(t, floats.Average(nil) == 2)
+	isTrue(t, ints.Take(0, 1).Average(nil) == 1)
+	isTrue(t, LambdaArray(invoices).Average(func(i invoice) *big.Rat { return i.amount }) == 20.29/3)
+
+	running := LambdaArray([]*big.Int{big.NewInt(1), big.NewInt(2)}).RunningSum(nil).Pointer().([]*big.Int)
+	isTrue(t, running[0].Int64() == 1 && running[1].Int64() == 3)
+}
+
+func TestBig_SumDecimal(t *testing.T) {
+	defer report(t, time.Now())
+	prices := LambdaArray([]float64{0.1, 0.2, 0.3})
+	isFalse(t, prices.Sum(nil).(float64) == 0.6)
+	isTrue(t, prices.SumDecimal(nil).Cmp(big.NewRat(6, 10)) == 0)
+	isTrue(t, prices.AverageDecimal(nil).Cmp(big.NewRat(2, 10)) == 0)
+
+	invoices := []invoice{
+		{"A1", big.NewRat(1999, 100), 19.99},
+		{"A2", nil, 0.01},
+	}
+	isTrue(t, LambdaArray(invoices).SumDecimal(func(i invoice) float64 { return i.price }).FloatString(2) == "20.00")
+	isTrue(t, LambdaArray(invoices).SumDecimal(func(i invoice) *big.Rat { return i.amount }).FloatString(2) == "19.99")
+	isTrue(t, LambdaArray([]float32{0.1, 0.7}).SumDecimal(nil).Cmp(big.NewRat(8, 10)) == 0)
+	isTrue(t, LambdaArray([]uint64{1 << 63, 1 << 63}).SumDecimal(nil).String() == "18446744073709551616/1")
+	isTrue(t, LambdaArray([]int{}).AverageDecimal(nil).Sign() == 0)
+}
@@ -0,0 +1,61 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// make Enumerable from source(chan T or <-chan T type)
+// elements are received as they arrive, the enumeration ends when source is closed.
+// a received element is gone from source, so a second terminal operator only sees what the first one left
+// and elements other receivers take are never seen, run ToArray first to query the same elements twice.
+// source is never closed by the Enumerable, it is owned by the sender
+func LambdaChan(source interface{}) Enumerable {
+	t := reflect.TypeOf(source)
+	if t == nil || t.Kind() != reflect.Chan || t.ChanDir()&reflect.RecvDir == 0 {
+		panic(fmt.Errorf("source type is %v, not receivable chan ", t))
+	}
+	value := reflect.ValueOf(source)
+	return &_enumerable{
+		elementType: t.Elem(),
+		iterate: func(done <-chan struct{}) iterator {
+			cases := []reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: value},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+			}
+			return func() (reflect.Value, bool) {
+				chosen, v, ok := reflect.Select(cases)
+				if chosen == 1 || !ok {
+					return reflect.Value{}, false
+				}
+				return v, true
+			}
+		},
+	}
+}
+
+func (p *_enumerable) ToChan(buffer int) (interface{}, func()) {
+	out := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, p.elementType), buffer)
+	done := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(done) })
+	}
+
+	go func() {
+		defer out.Close()
+		next := p.iterate(done)
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: out},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+		}
+		for v, ok := next(); ok; v, ok = next() {
+			cases[0].Send = v
+			if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+				return
+			}
+		}
+	}()
+	return out.Convert(reflect.ChanOf(reflect.RecvDir, p.elementType)).Interface(), stop
+}
@@ -0,0 +1,63 @@
+package lambda
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func Test__chan_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	ch := make(chan int)
+	go func() {
+		for i := 1; i <= 10; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	out, stop := LambdaChan(ch).
+		Filter(func(e int) bool { return e%2 == 0 }).
+		Map(func(e int) string { return fmt.Sprint(e) }).
+		ToChan(2)
+	defer stop()
+	var ret []string
+	for s := range out.(<-chan string) {
+		ret = append(ret, s)
+	}
+	isTrue(t, fmt.Sprint(ret) == "[2 4 6 8 10]")
+}
+
+func Test__chan_Take(t *testing.T) {
+	defer report(t, time.Now())
+	// unbounded stream, Take stops pulling after 3 elements
+	ch := make(chan int)
+	quit := make(chan struct{})
+	go func() {
+		for i := 1; ; i++ {
+			select {
+			case ch <- i:
+			case <-quit:
+				return
+			}
+		}
+	}()
+	defer close(quit)
+	ret := LambdaChan(ch).Take(2, 3).ToArray().Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret) == "[3 4 5]")
+}
+
+func Test__chan_Stop(t *testing.T) {
+	defer report(t, time.Now())
+	ch := make(chan int)
+	before := runtime.NumGoroutine()
+	out, stop := LambdaChan(ch).ToChan(0)
+	stop()
+	// out is closed without any element once the goroutine exits
+	_, ok := <-out.(<-chan int)
+	isFalse(t, ok)
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	isTrue(t, runtime.NumGoroutine() <= before)
+}
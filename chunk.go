@@ -0,0 +1,191 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// views of the elements [from, to), they share the elements of p without copy
+// the capacity is limited, so Append on a view never overwrites p
+func (p *_array) view(from, to int) Array {
+	return innerLambdaArray(p.value.Slice3(from, to, to))
+}
+
+func (p *_array) Chunk(size int) Array {
+	if size <= 0 {
+		panic(fmt.Sprintf("chunk size %d must be greater than 0", size))
+	}
+	s := p.slice()
+	length := s.Len()
+	ret := make([]Array, 0, (length+size-1)/size)
+	for i := 0; i < length; i += size {
+		end := i + size
+		if end > length {
+			end = length
+		}
+		ret = append(ret, s.view(i, end))
+	}
+	return LambdaArray(ret)
+}
+
+func (p *_array) Window(size, step int) Array {
+	if size <= 0 || step <= 0 {
+		panic(fmt.Sprintf("window size %d and step %d must be greater than 0", size, step))
+	}
+	s := p.slice()
+	length := s.Len()
+	ret := make([]Array, 0)
+	for i := 0; i+size <= length; i += step {
+		ret = append(ret, s.view(i, i+size))
+	}
+	return LambdaArray(ret)
+}
+
+// aggregate every window by express
+// express func(window Array) R, eg: func(w Array) float64 { return w.Average(nil) }
+func (p *_array) WindowMap(size, step int, express interface{}) Array {
+	var ot reflect.Type
+	argument(3, func() { ot = checkExpressRARTO(express, []reflect.Type{arrayType}) })
+	fn := reflect.ValueOf(express)
+	windows := p.Window(size, step).Pointer().([]Array)
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), len(windows), len(windows))
+	for i, w := range windows {
+		ret.Index(i).Set(fn.Call([]reflect.Value{reflect.ValueOf(&w).Elem()})[0])
+	}
+	return innerLambdaArray(ret)
+}
+
+// start of every full window of size values every step values
+func windowStarts(length, size, step int) []int {
+	if size <= 0 || step <= 0 {
+		panic(fmt.Sprintf("window size %d and step %d must be greater than 0", size, step))
+	}
+	starts := make([]int, 0)
+	for i := 0; i+size <= length; i += step {
+		starts = append(starts, i)
+	}
+	return starts
+}
+
+// sum of integers of t, the values leaving the window are subtracted,
+// the sum wraps around like Sum, so it is exact whatever the values are
+type slidingSum struct {
+	i int64
+	u uint64
+	t reflect.Type
+}
+
+func (s *slidingSum) add(v reflect.Value, sign int64) {
+	if isInt(s.t) {
+		s.i += sign * v.Int()
+	} else {
+		s.u += uint64(sign) * v.Uint()
+	}
+}
+
+func (s *slidingSum) value() reflect.Value {
+	ret := reflect.New(s.t).Elem()
+	if isInt(s.t) {
+		ret.SetInt(s.i)
+	} else {
+		ret.SetUint(s.u)
+	}
+	return ret
+}
+
+// sums of the windows, each integer enters and leaves the running sum once.
+// floats are summed window by window, subtracting them would spread an Inf or NaN to
+// the later windows and lose the small values next to a large one,
+// registered types and others than number Type are summed window by window by their Adder too
+func (p *_array) windowSums(size, step int, express interface{}) reflect.Value {
+	values := asInner(p.selectValues(express))
+	t := values.elementType
+	starts := windowStarts(values.Len(), size, step)
+	ret := reflect.MakeSlice(reflect.SliceOf(t), len(starts), len(starts))
+	if !(isInt(t) || isUint(t)) || registered(t) != nil {
+		add := Adder(t)
+		for i, start := range starts {
+			add.SetZero()
+			for j := start; j < start+size; j++ {
+				add.Add(values.value.Index(j))
+			}
+			ret.Index(i).Set(reflect.ValueOf(add.Value()))
+		}
+		return ret
+	}
+	sum := &slidingSum{t: t}
+	from, to := 0, 0
+	for i, start := range starts {
+		if start >= to {
+			sum = &slidingSum{t: t}
+			from, to = start, start
+		}
+		for ; from < start; from++ {
+			sum.add(values.value.Index(from), -1)
+		}
+		for ; to < start+size; to++ {
+			sum.add(values.value.Index(to), 1)
+		}
+		ret.Index(i).Set(sum.value())
+	}
+	return ret
+}
+
+func (p *_array) WindowSum(size, step int, express interface{}) Array {
+	return innerLambdaArray(p.windowSums(size, step, express))
+}
+
+func (p *_array) WindowAverage(size, step int, express interface{}) Array {
+	values := asInner(p.selectValues(express))
+	if !isNumber(values.elementType) || registered(values.elementType) != nil {
+		return values.WindowMap(size, step, func(w Array) float64 { return w.Average(nil) })
+	}
+	sums := values.windowSums(size, step, nil)
+	ret := make([]float64, sums.Len())
+	for i := range ret {
+		ret[i] = sums.Index(i).Convert(reflect.TypeOf(float64(0))).Float() / float64(size)
+	}
+	return LambdaArray(ret)
+}
+
+// maximums or minimums of the windows by a monotonic deque of indexes,
+// the values in the deque are decreasing for max, increasing for min, so the front is the extreme of the window
+func (p *_array) windowMaxOrMin(size, step int, express interface{}, isMax bool) Array {
+	values := asInner(p.selectValues(express))
+	length := values.Len()
+	starts := windowStarts(length, size, step)
+	ret := reflect.MakeSlice(reflect.SliceOf(values.elementType), len(starts), len(starts))
+	deque := make([]int, 0, size)
+	w := 0
+	for j := 0; j < length && w < len(starts); j++ {
+		tor, err := BasicComparator(values.value.Index(j).Interface())
+		if err != nil {
+			panic(err)
+		}
+		for len(deque) > 0 {
+			c := tor.CompareTo(values.value.Index(deque[len(deque)-1]).Interface())
+			if (isMax && c < 0) || (!isMax && c > 0) {
+				break
+			}
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, j)
+		if j+1 < starts[w]+size {
+			continue
+		}
+		for deque[0] < starts[w] {
+			deque = deque[1:]
+		}
+		ret.Index(w).Set(values.value.Index(deque[0]))
+		w++
+	}
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) WindowMax(size, step int, express interface{}) Array {
+	return p.windowMaxOrMin(size, step, express, true)
+}
+
+func (p *_array) WindowMin(size, step int, express interface{}) Array {
+	return p.windowMaxOrMin(size, step, express, false)
+}
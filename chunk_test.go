@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func Test__array_Chunk(t *testing.T) {
+	defer report(t, time.Now())
+	chunks := LambdaArray(makeUserArray()).Chunk(500).Pointer().([]Array)
+	isTrue(t, len(chunks) == count/500)
+	isTrue(t, chunks[1].Count(nil) == 500)
+
+	ret := LambdaArray([5]int{1, 2, 3, 4, 5}).Chunk(2).Map(func(c Array) int { return c.Sum(nil).(int) }).Pointer()
+	isTrue(t, fmt.Sprint(ret) == "[3 7 5]")
+
+	// append on a chunk does not overwrite the source
+	source := []int{1, 2, 3, 4}
+	first := LambdaArray(source).Chunk(2).Pointer().([]Array)[0]
+	first.Append(100)
+	isTrue(t, fmt.Sprint(source) == "[1 2 3 4]")
+}
+
+func Test__array_Window(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{1, 2, 3, 4, 5, 6})
+	windows := arr.Window(3, 2).Map(func(w Array) string { return fmt.Sprint(w.Pointer()) }).Pointer()
+	isTrue(t, fmt.Sprint(windows) == "[[1 2 3] [3 4 5]]")
+
+	avg := arr.WindowMap(3, 1, func(w Array) float64 { return w.Average(nil) }).Pointer()
+	isTrue(t, fmt.Sprint(avg) == "[2 3 4 5]")
+	max := arr.WindowMap(2, 2, func(w Array) int { return w.Max(nil).(int) }).Pointer()
+	isTrue(t, fmt.Sprint(max) == "[2 4 6]")
+
+	isTrue(t, arr.Window(10, 1).Count(nil) == 0)
+}
+
+func Test__array_WindowAggregate(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{5, 1, 4, 4, 2, 8, 3, 7, 6, 0})
+	// the same as aggregating every window by WindowMap for every size and step
+	for size := 1; size <= 4; size++ {
+		for step := 1; step <= 5; step++ {
+			sum := arr.WindowMap(size, step, func(w Array) int { return w.Sum(nil).(int) })
+			avg := arr.WindowMap(size, step, func(w Array) float64 { return w.Average(nil) })
+			max := arr.WindowMap(size, step, func(w Array) int { return w.Max(nil).(int) })
+			min := arr.WindowMap(size, step, func(w Array) int { return w.Min(nil).(int) })
+			isTrue(t, fmt.Sprint(arr.WindowSum(size, step, nil).Pointer()) == fmt.Sprint(sum.Pointer()))
+			isTrue(t, fmt.Sprint(arr.WindowAverage(size, step, nil).Pointer()) == fmt.Sprint(avg.Pointer()))
+			isTrue(t, fmt.Sprint(arr.WindowMax(size, step, nil).Pointer()) == fmt.Sprint(max.Pointer()))
+			isTrue(t, fmt.Sprint(arr.WindowMin(size, step, nil).Pointer()) == fmt.Sprint(min.Pointer()))
+		}
+	}
+
+	users := LambdaArray([]user{{age: 30}, {age: 20}, {age: 40}})
+	isTrue(t, fmt.Sprint(users.WindowSum(2, 1, Field("age")).Pointer()) == "[50 60]")
+	isTrue(t, fmt.Sprint(users.WindowMin(2, 1, "u => u.age").Pointer()) == "[20 20]")
+
+	// integers wrap around like Sum, other types are summed by their Adder
+	isTrue(t, fmt.Sprint(LambdaArray([]int8{100, 100, -100}).WindowSum(2, 1, nil).Pointer()) == "[-56 0]")
+	isTrue(t, fmt.Sprint(LambdaArray([]uint{1, 5, 2}).WindowSum(2, 1, nil).Pointer()) == "[6 7]")
+	// floats are not subtracted, so Inf, NaN and large values stay in their windows
+	floats := LambdaArray([]float64{math.Inf(1), 1, 2, 3})
+	isTrue(t, fmt.Sprint(floats.WindowSum(2, 1, nil).Pointer()) == "[+Inf 3 5]")
+	isTrue(t, fmt.Sprint(floats.WindowAverage(2, 1, nil).Pointer()) == "[+Inf 1.5 2.5]")
+	nan := LambdaArray([]float64{1, math.NaN(), 2, 3})
+	isTrue(t, fmt.Sprint(nan.WindowSum(2, 1, nil).Pointer()) == "[NaN NaN 5]")
+	large := LambdaArray([]float64{1e17, 1, 1, 1})
+	isTrue(t, fmt.Sprint(large.WindowSum(2, 1, nil).Pointer()) == "[1e+17 2 2]")
+	isTrue(t, fmt.Sprint(large.WindowAverage(2, 1, nil).Pointer()) == "[5e+16 1 1]")
+	bigs := LambdaArray([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+	isTrue(t, fmt.Sprint(bigs.WindowSum(2, 1, nil).Pointer()) == "[3 5]")
+	isTrue(t, fmt.Sprint(bigs.WindowAverage(2, 1, nil).Pointer()) == "[1.5 2.5]")
+
+	isTrue(t, arr.WindowMax(20, 1, nil).Count(nil) == 0)
+	defer func() {
+		isTrue(t, recover() != nil)
+	}()
+	arr.WindowSum(0, 1, nil)
+}
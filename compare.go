@@ -1,46 +1,65 @@
 package lambda
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strings"
+	"time"
 )
 
+var timeType = reflect.TypeOf(time.Time{})
+
+// NilOrder is the position of nil pointers in the total order
+type NilOrder int
+
+const (
+	// nil pointers are less than the others
+	NilFirst NilOrder = iota
+	// nil pointers are greater than the others
+	NilLast
+)
+
+// Compare of ele by the total order, nil pointers are first
 func BasicComparator(ele interface{}) (Compare, error) {
+	return TotalComparator(ele, NilFirst)
+}
+
+// TotalComparator returns Compare of ele, ele is a Compare, a struct with lambda tags or one of
+// number Type, string, bool, time.Time, []byte and pointers to them.
+// numbers are compared without overflow, NaN is less than -Inf and equals NaN,
+// false is less than true, pointers are compared by the pointed values,
+// nil pointers and untyped nil, eg: null of JSON, are ordered by nils
+func TotalComparator(ele interface{}, nils NilOrder) (Compare, error) {
 	if c, ok := ele.(Compare); ok {
 		return c, nil
 	}
-
-	k := reflect.TypeOf(ele).Kind()
-
-	support := []reflect.Kind{
-		reflect.Int,
-		reflect.Uint8,
-		reflect.Uint16,
-		reflect.Uint32,
-		reflect.Uint64,
-		reflect.Int8,
-		reflect.Int16,
-		reflect.Uint32,
-		reflect.Int64,
-		reflect.Float32,
-		reflect.Float64,
-		reflect.String,
+	if c, err := TaggedComparator(ele); err == nil && c.tags.sorts != nil {
+		return c, nil
 	}
-	if contain(support, k) {
-		return &BasicCompare{ele}, nil
+	t := reflect.TypeOf(ele)
+	if t != nil && !ordered(t) {
+		return nil, errors.New("unknown type")
 	}
-
-	return nil, errors.New("unknown type")
+	return &BasicCompare{ele, nils}, nil
 }
 
-func contain(kinds []reflect.Kind, target reflect.Kind) bool {
-	for _, k := range kinds {
-		if k == target {
-			return true
-		}
+// values of t are ordered by the total order
+func ordered(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	case reflect.Ptr:
+		return ordered(t.Elem())
 	}
 	return false
 }
@@ -51,56 +70,99 @@ type Compare interface {
 
 type BasicCompare struct {
 	v interface{}
+	// position of nil pointers
+	nils NilOrder
 }
 
+// three-way comparison, -1, 0 or 1
 func (p *BasicCompare) CompareTo(a interface{}) int {
-
-	vt, at := reflect.TypeOf(p.v), reflect.TypeOf(a)
-	if vt.Kind() != at.Kind() {
-		panic(fmt.Sprintf("%s is not %s", vt.String(), at.String()))
+	vv, av := reflect.ValueOf(p.v), reflect.ValueOf(a)
+	if !vv.IsValid() || !av.IsValid() {
+		// untyped nil is ordered like a nil pointer
+		null := func(v reflect.Value) bool { return !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() }
+		c := compareBool(!null(vv), !null(av))
+		if p.nils == NilLast {
+			return -c
+		}
+		return c
+	}
+	if vv.Kind() != av.Kind() {
+		panic(fmt.Sprintf("%s is not %v", vv.Type().String(), reflect.TypeOf(a)))
 	}
+	return compareValues(vv, av, p.nils)
+}
 
-	switch p.v.(type) {
-	case int:
-		return p.v.(int) - a.(int)
-	case uint8:
-		return int(p.v.(uint8) - a.(uint8))
-	case uint16:
-		return int(p.v.(uint16) - a.(uint16))
-	case uint32:
-		return int(p.v.(uint32) - a.(uint32))
-	case uint64:
-		return int(p.v.(uint64) - a.(uint64))
-	case int8:
-		return int(p.v.(int8) - a.(int8))
-	case int16:
-		return int(p.v.(int16) - a.(int16))
-	case int32:
-		return int(p.v.(int32) - a.(int32))
-	case int64:
-		return int(p.v.(int64) - a.(int64))
-	case float32:
-		v := p.v.(float32) - a.(float32)
-		if v > 0 {
-			return int(math.Ceil(float64(v)))
-		} else if v == 0 {
-			return 0
-		} else {
-			return int(math.Floor(float64(v)))
+// three-way comparison of a and b of the same kind
+func compareValues(a, b reflect.Value, nils NilOrder) int {
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloat(a.Float(), b.Float())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return compareBool(a.Bool(), b.Bool())
+	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Uint8 {
+			return bytes.Compare(a.Bytes(), b.Bytes())
 		}
-
-	case float64:
-		v := p.v.(float64) - a.(float64)
-		if v > 0 {
-			return int(math.Ceil(v))
-		} else if v == 0 {
+	case reflect.Ptr:
+		switch an, bn := a.IsNil(), b.IsNil(); {
+		case an && bn:
 			return 0
-		} else {
-			return int(math.Floor(v))
+		case an != bn:
+			// nil is first, the reverse when nils is NilLast
+			c := compareBool(bn, an)
+			if nils == NilLast {
+				return -c
+			}
+			return c
 		}
-	case string:
-		return strings.Compare(p.v.(string), a.(string))
-	default:
-		panic("unknown type " + at.String())
+		ae, be := a.Elem(), b.Elem()
+		if ae.Kind() != be.Kind() {
+			panic(fmt.Sprintf("%s is not %s", a.Type().String(), b.Type().String()))
+		}
+		return compareValues(ae, be, nils)
+	}
+	panic("unknown type " + a.Type().String())
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// NaN < -Inf < ... < +Inf, NaN equals NaN
+func compareFloat(a, b float64) int {
+	switch an, bn := math.IsNaN(a), math.IsNaN(b); {
+	case an && bn:
+		return 0
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return compareOrdered(a, b)
+}
+
+// false < true
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
 	}
+	return -1
 }
@@ -0,0 +1,91 @@
+package lambda
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func compareTo(a, b interface{}) int {
+	tor, err := BasicComparator(a)
+	if err != nil {
+		panic(err)
+	}
+	return tor.CompareTo(b)
+}
+
+func TestBasicComparator(t *testing.T) {
+	defer report(t, time.Now())
+	isTrue(t, compareTo(math.MaxInt64, -1) == 1)
+	isTrue(t, compareTo(math.MinInt64, 1) == -1)
+	isTrue(t, compareTo(uint8(1), uint8(255)) == -1)
+	isTrue(t, compareTo(uint64(math.MaxUint64), uint64(0)) == 1)
+	isTrue(t, compareTo(int8(-128), int8(127)) == -1)
+	isTrue(t, compareTo(uint(3), uint(3)) == 0)
+	isTrue(t, compareTo(0.1, 0.2) == -1)
+	isTrue(t, compareTo(float32(2.5), float32(2.25)) == 1)
+	isTrue(t, compareTo("a", "b") == -1)
+	isTrue(t, compareTo(false, true) == -1)
+	isTrue(t, compareTo([]byte("ab"), []byte("a")) == 1)
+
+	now := time.Now()
+	isTrue(t, compareTo(now, now.Add(time.Nanosecond)) == -1)
+	isTrue(t, compareTo(now, now.UTC()) == 0)
+
+	_, err := BasicComparator(struct{}{})
+	isTrue(t, err != nil)
+	_, err = BasicComparator([]int{1})
+	isTrue(t, err != nil)
+}
+
+func TestBasicComparator_Float(t *testing.T) {
+	defer report(t, time.Now())
+	nan, inf := math.NaN(), math.Inf(1)
+	isTrue(t, compareTo(nan, nan) == 0)
+	isTrue(t, compareTo(nan, -inf) == -1)
+	isTrue(t, compareTo(-inf, nan) == 1)
+	isTrue(t, compareTo(inf, math.MaxFloat64) == 1)
+	isTrue(t, compareTo(-inf, -math.MaxFloat64) == -1)
+
+	floats := LambdaArray([]float64{3, nan, inf, -inf, 1})
+	isTrue(t, floats.Max(nil) == inf)
+	isTrue(t, math.IsNaN(floats.Min(nil).(float64)))
+	isTrue(t, floats.Contains(inf))
+	isTrue(t, floats.Contains(nan))
+	sorted := floats.OrderBy(func(f float64) float64 { return f }).Pointer().([]float64)
+	isTrue(t, math.IsNaN(sorted[0]) && sorted[1] == -inf && sorted[4] == inf)
+}
+
+func TestTotalComparator_Pointer(t *testing.T) {
+	defer report(t, time.Now())
+	one, two := 1, 2
+	isTrue(t, compareTo(&one, &two) == -1)
+	isTrue(t, compareTo(&two, &two) == 0)
+	isTrue(t, compareTo((*int)(nil), &one) == -1)
+	isTrue(t, compareTo(&one, nil) == 1)
+	isTrue(t, compareTo((*int)(nil), nil) == 0)
+
+	last, _ := TotalComparator(&one, NilLast)
+	isTrue(t, last.CompareTo((*int)(nil)) == -1)
+
+	ptrs := LambdaArray([]*int{&two, nil, &one})
+	isTrue(t, *ptrs.Max(nil).(*int) == 2)
+	isTrue(t, ptrs.Min(nil).(*int) == nil)
+	isTrue(t, ptrs.Contains(&one))
+	sorted := ptrs.OrderBy(func(p *int) *int { return p }).Pointer().([]*int)
+	isTrue(t, sorted[0] == nil && *sorted[1] == 1 && *sorted[2] == 2)
+}
+
+func TestBasicComparator_Order(t *testing.T) {
+	defer report(t, time.Now())
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	days := LambdaArray([]time.Time{start.AddDate(0, 0, 2), start, start.AddDate(0, 0, 1)})
+	isTrue(t, days.Min(nil).(time.Time).Equal(start))
+	isTrue(t, days.Contains(start))
+	isTrue(t, LambdaArray([]bool{false, true, false}).Max(nil) == true)
+	isTrue(t, string(LambdaArray([][]byte{[]byte("b"), []byte("ab")}).Min(nil).([]byte)) == "ab")
+	isTrue(t, LambdaArray([]uint8{250, 10, 3}).Max(nil) == uint8(250))
+	isTrue(t, LambdaArray([]int64{math.MinInt64, math.MaxInt64}).Max(nil) == int64(math.MaxInt64))
+	keys := LambdaMap(map[uint8]int{200: 1, 1: 2, 100: 3}, MapOptions{SortKeys: true}).Keys().Pointer()
+	isTrue(t, len(keys.([]uint8)) == 3 && keys.([]uint8)[0] == 1 && keys.([]uint8)[2] == 200)
+}
@@ -0,0 +1,268 @@
+package lambda
+
+import (
+	"context"
+	"reflect"
+)
+
+// ContextArray runs the long-running operators of Array under a context.Context
+// the cancellation is checked between elements, the operator stops and returns ctx.Err() when ctx is done.
+// the other operators do not check ctx, call them on the results or stream the elements with AsEnumerable
+type ContextArray interface {
+
+	// see Array.Filter
+	Filter(express interface{}) (Array, error)
+
+	// see Array.Map
+	Map(express interface{}) (Array, error)
+
+	// see Array.Sort
+	Sort(express interface{}) (Array, error)
+
+	// see Array.SortMT, all spawned goroutines stop when ctx is done
+	SortMT(express interface{}) (Array, error)
+
+	// see Array.Any
+	Any(express interface{}) (bool, error)
+
+	// see Array.Count
+	Count(express interface{}) (int, error)
+
+	// see Array.First
+	First(express interface{}) (interface{}, error)
+
+	// see Array.DistinctBy
+	DistinctBy(express interface{}) (Array, error)
+
+	// see Array.GroupBy
+	GroupBy(express interface{}) (Array, error)
+
+	// see Array.ToLookup
+	ToLookup(express interface{}) (Lookup, error)
+
+	// see Array.InnerJoin
+	InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) (Array, error)
+
+	// see Array.LeftJoin
+	LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) (Array, error)
+
+	// see Array.GroupJoin
+	GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) (Array, error)
+
+	// see Array.Aggregate
+	Aggregate(seed, express, resultSelector interface{}) (interface{}, error)
+
+	// see Array.Sum
+	Sum(express interface{}) (interface{}, error)
+
+	// see Array.Average
+	Average(express interface{}) (float64, error)
+
+	// see Array.Max
+	Max(express interface{}) (interface{}, error)
+
+	// see Array.Min
+	Min(express interface{}) (interface{}, error)
+}
+
+type _context struct {
+	*_array
+	ctx context.Context
+}
+
+func (p *_array) WithContext(ctx context.Context) ContextArray {
+	return &_context{p, ctx}
+}
+
+// raised by the guarded express when ctx is done
+type canceled struct {
+	err error
+}
+
+// bind express by bind, then wrap it to check ctx before each call, the type of express is kept
+func (p *_context) guard(express interface{}, bind func(interface{}, reflect.Type) interface{}) interface{} {
+	express = bind(express, p.elementType)
+	fn := reflect.ValueOf(express)
+	if fn.Kind() != reflect.Func {
+		return express
+	}
+	return reflect.MakeFunc(fn.Type(), func(args []reflect.Value) []reflect.Value {
+		if err := p.ctx.Err(); err != nil {
+			panic(canceled{err})
+		}
+		return fn.Call(args)
+	}).Interface()
+}
+
+// express nil is the element itself, so the guard checks ctx between elements
+func (p *_context) self(express interface{}) interface{} {
+	if express != nil {
+		return express
+	}
+	ft := reflect.FuncOf([]reflect.Type{p.elementType}, []reflect.Type{p.elementType}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		return args
+	}).Interface()
+}
+
+// bind of the expresses which are not bound by Array, eg: the result selectors
+func unbound(express interface{}, _ reflect.Type) interface{} {
+	return express
+}
+
+// run fn, returns ctx.Err() when the guarded express was canceled
+func (p *_context) run(fn func()) (err error) {
+	if err = p.ctx.Err(); err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			c, ok := r.(canceled)
+			if !ok {
+				panic(r)
+			}
+			err = c.err
+		}
+	}()
+	fn()
+	return nil
+}
+
+func (p *_context) Filter(express interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		ret = p._array.Filter(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) Map(express interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		ret = p._array.Map(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) Sort(express interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		// sort a new Array, so p is untouched when canceled
+		ret = innerLambdaArray(p.value).Sort(p.guard(express, bindLess))
+	})
+	return
+}
+
+func (p *_context) SortMT(express interface{}) (Array, error) {
+	if err := p.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p._array.sortMT(p.ctx, express)
+}
+
+func (p *_context) Any(express interface{}) (ret bool, err error) {
+	err = p.run(func() {
+		ret = p._array.Any(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) Count(express interface{}) (ret int, err error) {
+	err = p.run(func() {
+		ret = p._array.Count(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) First(express interface{}) (ret interface{}, err error) {
+	var found error
+	if err = p.run(func() {
+		ret, found = p._array.First(p.guard(express, bindExpress))
+	}); err != nil {
+		return nil, err
+	}
+	return ret, found
+}
+
+func (p *_context) DistinctBy(express interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		ret = p._array.DistinctBy(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) GroupBy(express interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		ret = p._array.GroupBy(p.guard(express, bindExpress))
+	})
+	return
+}
+
+func (p *_context) ToLookup(express interface{}) (ret Lookup, err error) {
+	err = p.run(func() {
+		ret = p._array.ToLookup(p.guard(express, bindExpress))
+	})
+	return
+}
+
+// guard the keys of a join, the inner key is bound to the elements of inner
+func (p *_context) joinKeys(inner Array, outerKey, innerKey interface{}) (interface{}, interface{}) {
+	innerKey = bindExpress(innerKey, asInner(inner).elementType)
+	return p.guard(outerKey, bindExpress), p.guard(innerKey, unbound)
+}
+
+func (p *_context) InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		outerKey, innerKey = p.joinKeys(other, outerKey, innerKey)
+		ret = p._array.InnerJoin(other, outerKey, innerKey, p.guard(resultSelector, unbound))
+	})
+	return
+}
+
+func (p *_context) LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		outerKey, innerKey = p.joinKeys(other, outerKey, innerKey)
+		ret = p._array.LeftJoin(other, outerKey, innerKey, p.guard(resultSelector, unbound), defaultInner)
+	})
+	return
+}
+
+func (p *_context) GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) (ret Array, err error) {
+	err = p.run(func() {
+		outerKey, innerKey = p.joinKeys(other, outerKey, innerKey)
+		ret = p._array.GroupJoin(other, outerKey, innerKey, p.guard(resultSelector, unbound))
+	})
+	return
+}
+
+func (p *_context) Aggregate(seed, express, resultSelector interface{}) (ret interface{}, err error) {
+	err = p.run(func() {
+		ret = p._array.Aggregate(seed, p.guard(express, unbound), resultSelector)
+	})
+	return
+}
+
+func (p *_context) Sum(express interface{}) (ret interface{}, err error) {
+	err = p.run(func() {
+		ret = p._array.Sum(p.guard(p.self(express), bindExpress))
+	})
+	return
+}
+
+func (p *_context) Average(express interface{}) (ret float64, err error) {
+	err = p.run(func() {
+		ret = p._array.Average(p.guard(p.self(express), bindExpress))
+	})
+	return
+}
+
+func (p *_context) Max(express interface{}) (ret interface{}, err error) {
+	err = p.run(func() {
+		ret = p._array.Max(p.guard(p.self(express), bindExpress))
+	})
+	return
+}
+
+func (p *_context) Min(express interface{}) (ret interface{}, err error) {
+	err = p.run(func() {
+		ret = p._array.Min(p.guard(p.self(express), bindExpress))
+	})
+	return
+}
@@ -0,0 +1,149 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestContextArray_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := LambdaArray(makeIntArray()).WithContext(ctx).Filter(func(e int) bool {
+		calls++
+		if e == 100 {
+			cancel()
+		}
+		return e%2 == 0
+	})
+	isTrue(t, errors.Is(err, context.Canceled))
+	isTrue(t, calls == 100)
+
+	ret, err := LambdaArray([]int{1, 2, 3}).WithContext(context.Background()).Map(func(e int) string { return fmt.Sprint(e) })
+	isTrue(t, err == nil && fmt.Sprint(ret.Pointer()) == "[1 2 3]")
+
+	_, err = LambdaArray([]int{1, 2, 3}).WithContext(ctx).Count(nil)
+	isTrue(t, errors.Is(err, context.Canceled))
+}
+
+func TestContextArray_Sort(t *testing.T) {
+	defer report(t, time.Now())
+	want := []int{5, 3, 1, 4, 2}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := LambdaArray(want).WithContext(ctx).Sort(func(a, b int) bool {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return a < b
+	})
+	isTrue(t, errors.Is(err, context.Canceled))
+	isTrue(t, fmt.Sprint(want) == "[5 3 1 4 2]")
+
+	ret, err := LambdaArray(want).WithContext(context.Background()).Sort(func(a, b int) bool { return a < b })
+	isTrue(t, err == nil && fmt.Sprint(ret.Pointer()) == "[1 2 3 4 5]")
+
+	// nil, Field and string expresses are bound before they are guarded
+	ctx, cancel = context.WithCancel(context.Background())
+	ticks := []tick{{5, cancel}, {3, cancel}, {1, cancel}, {4, cancel}, {2, cancel}}
+	_, err = LambdaArray(ticks).WithContext(ctx).Sort(nil)
+	isTrue(t, errors.Is(err, context.Canceled))
+	ret, err = LambdaArray(want).WithContext(context.Background()).Sort("(a, b) => a > b")
+	isTrue(t, err == nil && fmt.Sprint(ret.Pointer()) == "[5 4 3 2 1]")
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = LambdaArray(want).WithContext(ctx).Filter("x => x > 2")
+	isTrue(t, errors.Is(err, context.Canceled))
+}
+
+// tick cancels the context when it is compared
+type tick struct {
+	n      int
+	cancel context.CancelFunc
+}
+
+func (k tick) CompareTo(a interface{}) int {
+	k.cancel()
+	return k.n - a.(tick).n
+}
+
+func TestContextArray_SortMT(t *testing.T) {
+	defer report(t, time.Now())
+	want := make([]int, count)
+	for i := 0; i < count; i++ {
+		want[i] = rand.Intn(count * 10)
+	}
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := LambdaArray(want).WithContext(ctx).SortMT(func(a, b int) bool {
+		time.Sleep(time.Microsecond)
+		return a > b
+	})
+	isTrue(t, errors.Is(err, context.DeadlineExceeded))
+	for i := 0; i < 1000 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	isTrue(t, runtime.NumGoroutine() <= before)
+
+	ret, err := LambdaArray([]int{3, 1, 2}).WithContext(context.Background()).SortMT(func(a, b int) bool { return a > b })
+	isTrue(t, err == nil && fmt.Sprint(ret.Pointer()) == "[1 2 3]")
+}
+
+func TestContextArray_Operators(t *testing.T) {
+	defer report(t, time.Now())
+	us := makeUsers()[:4]
+	orders := []order{{"Edith", 10}, {"Abraham", 5}, {"Edith", 7}}
+	bg := LambdaArray(us).WithContext(context.Background())
+	groups, err := bg.GroupBy(func(u user) byte { return u.name[0] })
+	isTrue(t, err == nil && groups.Count(nil) == 3)
+	lookup, err := bg.ToLookup(Field("age"))
+	isTrue(t, err == nil && lookup.Contains(40))
+	distinct, err := bg.DistinctBy("u => u.age / 10")
+	isTrue(t, err == nil && distinct.Count(nil) == 2)
+	joined, err := bg.InnerJoin(LambdaArray(orders), Field("name"), Field("owner"), func(u user, o order) int { return o.total })
+	isTrue(t, err == nil && fmt.Sprint(joined.Pointer()) == "[5 10 7]")
+	left, err := bg.LeftJoin(LambdaArray(orders), Field("name"), Field("owner"), func(u user, o order) int { return o.total }, nil)
+	isTrue(t, err == nil && left.Count(nil) == 5)
+	counts, err := bg.GroupJoin(LambdaArray(orders), Field("name"), Field("owner"), func(u user, os Array) int { return os.Count(nil) })
+	isTrue(t, err == nil && fmt.Sprint(counts.Pointer()) == "[1 2 0 0]")
+	total, err := bg.Aggregate(0, func(acc int, u user) int { return acc + u.age }, nil)
+	isTrue(t, err == nil && total == 111)
+	sum, err := bg.Sum(Field("age"))
+	isTrue(t, err == nil && sum == 111)
+	avg, err := LambdaArray([]int{1, 2}).WithContext(context.Background()).Average(nil)
+	isTrue(t, err == nil && avg == 1.5)
+	max, err := bg.Max(Field("age"))
+	isTrue(t, err == nil && max.(user).name == "Charles")
+	min, err := LambdaArray([]int{3, 1, 2}).WithContext(context.Background()).Min(nil)
+	isTrue(t, err == nil && min == 1)
+
+	// the keys, the result selectors and the elements compared by nil express are guarded
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err = LambdaArray(makeIntArray()).WithContext(ctx).GroupBy(func(e int) int {
+		calls++
+		if calls == 100 {
+			cancel()
+		}
+		return e % 3
+	})
+	isTrue(t, errors.Is(err, context.Canceled) && calls == 100)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = LambdaArray(us).WithContext(ctx).InnerJoin(LambdaArray(orders), Field("name"), func(o order) string {
+		cancel()
+		return o.owner
+	}, func(u user, o order) int { return o.total })
+	isTrue(t, errors.Is(err, context.Canceled))
+
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = LambdaArray([]tick{{5, cancel}, {3, cancel}, {1, cancel}}).WithContext(ctx).Max(nil)
+	isTrue(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,334 @@
+package lambda
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// a CSV column of a struct field
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+var csvCache sync.Map
+
+// the columns of struct t in declaration order, cached by t when there is no error
+//
+//	csv:"name" the column name of the field, the field name by default
+//	csv:"-"    the field is not a column
+//
+// exported fields of string, bool, number, encoding.TextMarshaler and TextUnmarshaler types
+// and pointers to them are columns, other fields without tag are skipped, with tag are an error.
+// embedded structs and exported pointers to struct without tag are flattened
+func csvColumns(t reflect.Type) ([]csvColumn, error) {
+	if columns, ok := csvCache.Load(t); ok {
+		return columns.([]csvColumn), nil
+	}
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("element type %s is not a struct or a pointer to struct", t.String()))
+	}
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("csv")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged && !csvText(f.Type) {
+			st := f.Type
+			if st.Kind() == reflect.Ptr && f.PkgPath == "" {
+				st = st.Elem()
+			}
+			if st.Kind() == reflect.Struct {
+				embedded, err := csvColumns(st)
+				if err != nil {
+					return nil, err
+				}
+				for _, c := range embedded {
+					columns = append(columns, csvColumn{c.name, append([]int{i}, c.index...)})
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if !csvText(f.Type) {
+			if !tagged {
+				continue
+			}
+			return nil, fmt.Errorf("field %s.%s of %s is not a csv column", t.String(), f.Name, f.Type.String())
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		columns = append(columns, csvColumn{name, f.Index})
+	}
+	actual, _ := csvCache.LoadOrStore(t, columns)
+	return actual.([]csvColumn), nil
+}
+
+// values of t are written as the text of a column
+func csvText(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+	if pt.Implements(textMarshalerType) && pt.Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return t.Elem().Kind() != reflect.Ptr && csvText(t.Elem())
+	case reflect.String, reflect.Bool:
+		return true
+	}
+	return isNumber(t)
+}
+
+// parse s into v, the empty string is the zero value, nil for pointers
+func parseText(v reflect.Value, s string) error {
+	if s == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(s)
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case isInt(v.Type()):
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case isUint(v.Type()):
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case isFloat(v.Type()):
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// text of v, nil pointers and invalid v are the empty string
+func formatText(v reflect.Value) (string, error) {
+	if !v.IsValid() {
+		return "", nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if m, ok := addressable(v).Addr().Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String(), nil
+	case v.Kind() == reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case isInt(v.Type()):
+		return strconv.FormatInt(v.Int(), 10), nil
+	case isUint(v.Type()):
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+}
+
+// StreamCSV makes Enumerable of the records of r decoded into sample's type, a struct or a pointer to struct.
+// the first record is the header, columns are matched to fields by the csv tag or the field name ignoring case,
+// unknown columns are skipped, fields without column and empty cells are left zero,
+// embedded struct pointers are allocated by their first non-empty cell.
+// the header is read by the first pull and records are decoded one by one as they are pulled,
+// a second terminal operator continues at the record after the last one the first operator took.
+// the enumeration ends at the first error, err returns it afterwards, *RecordError when a value can not be parsed,
+// a field tagged csv which is not of a column type is an error before any record is read
+func StreamCSV(r io.Reader, sample interface{}) (en Enumerable, err func() error) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		panic("sample is nil")
+	}
+	st := derefType(t)
+	columns, first := csvColumns(st)
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	var fields []*csvColumn
+	fail := func(e error) (reflect.Value, bool) {
+		if first == nil {
+			first = e
+		}
+		return reflect.Value{}, false
+	}
+	en = &_enumerable{
+		elementType: t,
+		iterate: func(done <-chan struct{}) iterator {
+			return func() (reflect.Value, bool) {
+				if first != nil {
+					return reflect.Value{}, false
+				}
+				select {
+				case <-done:
+					return reflect.Value{}, false
+				default:
+				}
+				if fields == nil {
+					header, e := cr.Read()
+					if e == io.EOF {
+						return reflect.Value{}, false
+					}
+					if e != nil {
+						return fail(e)
+					}
+					fields = matchColumns(columns, header)
+				}
+				record, e := cr.Read()
+				if e == io.EOF {
+					return reflect.Value{}, false
+				}
+				if e != nil {
+					return fail(e)
+				}
+				ptr := reflect.New(st)
+				for i, s := range record {
+					if i >= len(fields) || fields[i] == nil || s == "" {
+						continue
+					}
+					if e := parseText(csvField(ptr.Elem(), fields[i].index), s); e != nil {
+						line, _ := cr.FieldPos(i)
+						return fail(&RecordError{line, fields[i].name, e})
+					}
+				}
+				if t.Kind() == reflect.Ptr {
+					return ptr, true
+				}
+				return ptr.Elem(), true
+			}
+		},
+	}
+	return en, func() error { return first }
+}
+
+// the field of v at index, nil embedded pointers on the way are allocated
+func csvField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// the column of each header name, nil when the name is not a column
+func matchColumns(columns []csvColumn, header []string) []*csvColumn {
+	fields := make([]*csvColumn, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		for j := range columns {
+			if columns[j].name == name {
+				fields[i] = &columns[j]
+				break
+			}
+			if fields[i] == nil && strings.EqualFold(columns[j].name, name) {
+				fields[i] = &columns[j]
+			}
+		}
+	}
+	return fields
+}
+
+// FromCSV reads all the records of r into out, out is a *[]T, see StreamCSV.
+// the returned Array is over *out, it holds the records read before the error when err is not nil
+func FromCSV(r io.Reader, out interface{}) (Array, error) {
+	pv := reflect.ValueOf(out)
+	if pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Slice {
+		panic(fmt.Errorf("out type is %v, not a pointer to slice", reflect.TypeOf(out)))
+	}
+	sv := pv.Elem()
+	en, err := StreamCSV(r, reflect.Zero(sv.Type().Elem()).Interface())
+	next := en.(*_enumerable).iterate(nil)
+	for v, ok := next(); ok; v, ok = next() {
+		sv.Set(reflect.Append(sv, v))
+	}
+	return LambdaArray(sv.Interface()), err()
+}
+
+func (p *_enumerable) WriteCSV(w io.Writer) error {
+	st := derefType(p.elementType)
+	columns, err := csvColumns(st)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	steps := make([]func(reflect.Value) reflect.Value, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+		steps[i] = fieldStep(st, c.index)
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	next := p.iterate(nil)
+	for v, ok := next(); ok; v, ok = next() {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return errors.New("nil element")
+			}
+			v = v.Elem()
+		}
+		for i := range columns {
+			var err error
+			if record[i], err = formatText(steps[i](v)); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (p *_array) WriteCSV(w io.Writer) error {
+	return p.AsEnumerable().WriteCSV(w)
+}
@@ -0,0 +1,151 @@
+package lambda
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type audit struct {
+	At time.Time `csv:"at"`
+}
+
+type sale struct {
+	Region  string  `csv:"region"`
+	Amount  float64 `csv:"amount"`
+	Units   int     `csv:"units"`
+	Paid    bool    `csv:"paid"`
+	Note    *string `csv:"note"`
+	private int
+	Skipped []string `csv:"-"`
+	audit
+}
+
+const sales = `region,amount,units,paid,note,at,unknown
+north,10.5,3,true,,2026-01-02T00:00:00Z,x
+south,4,1,false,late,2026-01-03T00:00:00Z,y
+north,2.25,7,true,,2026-01-04T00:00:00Z,z
+`
+
+func TestFromCSV(t *testing.T) {
+	defer report(t, time.Now())
+	var rs []sale
+	arr, err := FromCSV(strings.NewReader(sales), &rs)
+	isTrue(t, err == nil)
+	isTrue(t, len(rs) == 3 && arr.Count(nil) == 3)
+	isTrue(t, arr.Sum(Field("Amount")) == 16.75)
+	isTrue(t, rs[1].Note != nil && *rs[1].Note == "late" && rs[0].Note == nil)
+	isTrue(t, rs[2].At.Day() == 4 && rs[2].Units == 7 && rs[2].Paid)
+	isTrue(t, arr.GroupBy(Field("Region")).Count(nil) == 2)
+
+	// header names match ignoring case, missing columns are zero
+	var ps []*sale
+	_, err = FromCSV(strings.NewReader("Region,UNITS\neast,5\n"), &ps)
+	isTrue(t, err == nil && ps[0].Region == "east" && ps[0].Units == 5 && ps[0].Amount == 0)
+
+	rs = nil
+	arr, err = FromCSV(strings.NewReader("region,units\nwest,1\nwest,one\nwest,3\n"), &rs)
+	var re *RecordError
+	isTrue(t, errors.As(err, &re) && re.Line == 3 && re.Column == "units")
+	isTrue(t, arr.Count(nil) == 1)
+}
+
+func TestStreamCSV(t *testing.T) {
+	defer report(t, time.Now())
+	var b strings.Builder
+	b.WriteString("region,units\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, "r%d,%d\n", i%3, i)
+	}
+	r := &countingReader{r: strings.NewReader(b.String())}
+	en, err := StreamCSV(r, sale{})
+	first, _ := en.Filter(func(e sale) bool { return e.Region == "r2" }).First(func(e sale) bool { return e.Units == 5 })
+	isTrue(t, err() == nil)
+	isTrue(t, first.(sale).Region == "r2")
+	// the input is read as the records are pulled
+	isTrue(t, r.n < b.Len())
+
+	en, err = StreamCSV(strings.NewReader(b.String()), &sale{})
+	isTrue(t, en.Map(Field("Units")).Take(0, 3).ToArray().Sum(nil) == 3)
+	isTrue(t, err() == nil)
+}
+
+func TestWriteCSV(t *testing.T) {
+	defer report(t, time.Now())
+	var rs []sale
+	arr, _ := FromCSV(strings.NewReader(sales), &rs)
+	var out bytes.Buffer
+	isTrue(t, arr.Filter(Field("Paid")).WriteCSV(&out) == nil)
+	isTrue(t, out.String() == "region,amount,units,paid,note,at\n"+
+		"north,10.5,3,true,,2026-01-02T00:00:00Z\n"+
+		"north,2.25,7,true,,2026-01-04T00:00:00Z\n")
+
+	var back []sale
+	_, err := FromCSV(&out, &back)
+	isTrue(t, err == nil && len(back) == 2 && back[1].Amount == 2.25)
+
+	out.Reset()
+	en, _ := StreamCSV(strings.NewReader(sales), &sale{})
+	isTrue(t, en.Take(1, 1).WriteCSV(&out) == nil)
+	isTrue(t, strings.HasSuffix(out.String(), "\nsouth,4,1,false,late,2026-01-03T00:00:00Z\n"))
+
+	defer func() {
+		isTrue(t, recover() != nil)
+	}()
+	_ = LambdaArray([]int{1}).WriteCSV(io.Discard)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	if len(p) > 64 {
+		p = p[:64]
+	}
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+type Contact struct {
+	Email string `csv:"email"`
+	Phone string `csv:"phone"`
+}
+
+type customer struct {
+	Name  string `csv:"name"`
+	Score int    `csv:"score"`
+	VIP   bool   `csv:"vip"`
+	Tags  []string
+	*Contact
+}
+
+func TestCSV_Embedded(t *testing.T) {
+	defer report(t, time.Now())
+	var cs []customer
+	_, err := FromCSV(strings.NewReader("name,score,vip,email,phone\nann,,,a@x.io,\nbob,3,true,,\n"), &cs)
+	isTrue(t, err == nil && len(cs) == 2)
+	// empty cells are zero, embedded pointers are allocated by a non-empty cell
+	isTrue(t, cs[0].Score == 0 && !cs[0].VIP && cs[0].Contact != nil && cs[0].Email == "a@x.io")
+	isTrue(t, cs[1].Score == 3 && cs[1].VIP && cs[1].Contact == nil)
+
+	var out bytes.Buffer
+	isTrue(t, LambdaArray(cs).WriteCSV(&out) == nil)
+	isTrue(t, out.String() == "name,score,vip,email,phone\nann,0,false,a@x.io,\nbob,3,true,,\n")
+
+	// a tagged field which is not a column is an error, not a panic
+	type bad struct {
+		Name string   `csv:"name"`
+		Tags []string `csv:"tags"`
+	}
+	var bs []bad
+	_, err = FromCSV(strings.NewReader("name\nx\n"), &bs)
+	isTrue(t, err != nil && len(bs) == 0)
+	isTrue(t, LambdaArray([]bad{{}}).WriteCSV(io.Discard) != nil)
+}
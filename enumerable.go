@@ -0,0 +1,211 @@
+package lambda
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// Enumerable is the lazy form of Array
+// operators compose into a pipeline and elements are pulled on demand,
+// nothing is executed until a terminal operator (First, Any, Count, ToArray...) is called
+type Enumerable interface {
+
+	// lazy filter
+	// eg: en.Filter(func(ele int) bool{ return ele>10})
+	Filter(express interface{}) Enumerable
+
+	// lazy map
+	// express func(el T) R{ return R }
+	Map(express interface{}) Enumerable
+
+	// skip and take count elements, stops pulling from upstream once count elements were taken
+	Take(skip, count int) Enumerable
+
+	// Returns the first element that satisfies the condition
+	First(express interface{}) (interface{}, error)
+
+	// Determines whether any element satisfies the condition
+	Any(express interface{}) bool
+
+	// Returns a number indicating how many elements satisfy the condition
+	Count(express interface{}) int
+
+	// execute the pipeline into an Array
+	ToArray() Array
+
+	// execute the pipeline in a goroutine and emit the elements to a new channel,
+	// out is a <-chan T with buffer size, it is closed when the pipeline ends.
+	// call stop when the consumer stops reading early, the goroutine exits and out is closed
+	ToChan(buffer int) (out interface{}, stop func())
+
+	// write the elements to w as CSV, the header row of the column names first, see StreamCSV
+	WriteCSV(w io.Writer) error
+
+	// write the elements to w as JSON Lines, one JSON value per line
+	WriteJSONLines(w io.Writer) error
+}
+
+// iterator returns the next element, ok is false when there are no more elements
+type iterator func() (v reflect.Value, ok bool)
+
+type _enumerable struct {
+	// element type
+	elementType reflect.Type
+	// makes a new iterator on every enumeration
+	// the iterator stops when done is closed, nil done never stops
+	iterate func(done <-chan struct{}) iterator
+}
+
+// make Enumerable from source(TIn[] type)
+// source support array or slice type
+func LambdaEnumerable(source interface{}) Enumerable {
+	return LambdaArray(source).AsEnumerable()
+}
+
+func (p *_array) AsEnumerable() Enumerable {
+	value := p.value
+	return &_enumerable{
+		elementType: p.elementType,
+		iterate: func(done <-chan struct{}) iterator {
+			i, length := 0, value.Len()
+			return func() (reflect.Value, bool) {
+				if i >= length {
+					return reflect.Value{}, false
+				}
+				i++
+				return value.Index(i - 1), true
+			}
+		},
+	}
+}
+
+func (p *_enumerable) Filter(express interface{}) Enumerable {
+	express = bindExpress(express, p.elementType)
+	checkExpress(
+		reflect.TypeOf(express),
+		[]reflect.Type{p.elementType},
+		[]reflect.Type{reflect.TypeOf(true)})
+	fn := reflect.ValueOf(express)
+	upstream := p.iterate
+	return &_enumerable{
+		elementType: p.elementType,
+		iterate: func(done <-chan struct{}) iterator {
+			next := upstream(done)
+			return func() (reflect.Value, bool) {
+				for v, ok := next(); ok; v, ok = next() {
+					if fn.Call([]reflect.Value{v})[0].Interface().(bool) {
+						return v, true
+					}
+				}
+				return reflect.Value{}, false
+			}
+		},
+	}
+}
+
+func (p *_enumerable) Map(express interface{}) Enumerable {
+	express = bindExpress(express, p.elementType)
+	ot := checkExpressRARTO(express, []reflect.Type{p.elementType})
+	fn := reflect.ValueOf(express)
+	upstream := p.iterate
+	return &_enumerable{
+		elementType: ot,
+		iterate: func(done <-chan struct{}) iterator {
+			next := upstream(done)
+			return func() (reflect.Value, bool) {
+				v, ok := next()
+				if !ok {
+					return reflect.Value{}, false
+				}
+				return fn.Call([]reflect.Value{v})[0], true
+			}
+		},
+	}
+}
+
+func (p *_enumerable) Take(skip, count int) Enumerable {
+	upstream := p.iterate
+	return &_enumerable{
+		elementType: p.elementType,
+		iterate: func(done <-chan struct{}) iterator {
+			next := upstream(done)
+			skipped, taken := 0, 0
+			return func() (reflect.Value, bool) {
+				for ; skipped < skip; skipped++ {
+					if _, ok := next(); !ok {
+						return reflect.Value{}, false
+					}
+				}
+				if taken >= count {
+					return reflect.Value{}, false
+				}
+				taken++
+				return next()
+			}
+		},
+	}
+}
+
+func (p *_enumerable) predicate(express interface{}) func(v reflect.Value) bool {
+	express = bindExpress(express, p.elementType)
+	if express == nil {
+		return func(reflect.Value) bool { return true }
+	}
+	checkExpress(
+		reflect.TypeOf(express),
+		[]reflect.Type{p.elementType},
+		[]reflect.Type{reflect.TypeOf(true)})
+	fn := reflect.ValueOf(express)
+	return func(v reflect.Value) bool {
+		return fn.Call([]reflect.Value{v})[0].Interface().(bool)
+	}
+}
+
+func (p *_enumerable) First(express interface{}) (interface{}, error) {
+	match := p.predicate(express)
+	next := p.iterate(nil)
+	empty := true
+	for v, ok := next(); ok; v, ok = next() {
+		empty = false
+		if match(v) {
+			return v.Interface(), nil
+		}
+	}
+	if empty {
+		return nil, errors.New("empty array")
+	}
+	return nil, errors.New("not found")
+}
+
+func (p *_enumerable) Any(express interface{}) bool {
+	match := p.predicate(express)
+	next := p.iterate(nil)
+	for v, ok := next(); ok; v, ok = next() {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *_enumerable) Count(express interface{}) int {
+	match := p.predicate(express)
+	next := p.iterate(nil)
+	count := 0
+	for v, ok := next(); ok; v, ok = next() {
+		if match(v) {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *_enumerable) ToArray() Array {
+	ret := reflect.MakeSlice(reflect.SliceOf(p.elementType), 0, 0)
+	next := p.iterate(nil)
+	for v, ok := next(); ok; v, ok = next() {
+		ret = reflect.Append(ret, v)
+	}
+	return innerLambdaArray(ret)
+}
@@ -0,0 +1,48 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test__enumerable_First(t *testing.T) {
+	defer report(t, time.Now())
+	calls := 0
+	en := LambdaArray(makeIntArray()).AsEnumerable().Map(func(e int) int {
+		calls++
+		return e * 2
+	}).Filter(func(e int) bool { return e > 10 })
+
+	ret, err := en.First(nil)
+	isTrue(t, err == nil && ret == 12)
+	isTrue(t, calls == 6)
+
+	_, err = en.First(func(e int) bool { return e < 0 })
+	isTrue(t, err != nil)
+	isTrue(t, calls == 6+count)
+}
+
+func Test__enumerable_Take(t *testing.T) {
+	defer report(t, time.Now())
+	calls := 0
+	ret := LambdaEnumerable(makeIntArray()).Filter(func(e int) bool {
+		calls++
+		return e%2 == 0
+	}).Take(2, 3).ToArray().Pointer().([]int)
+	isTrue(t, fmt.Sprint(ret) == "[6 8 10]")
+	isTrue(t, calls == 10)
+
+	empty := LambdaEnumerable([]int{1, 2, 3}).Take(10, 10).ToArray().Pointer().([]int)
+	isTrue(t, len(empty) == 0)
+}
+
+func Test__enumerable_Count(t *testing.T) {
+	defer report(t, time.Now())
+	us := makeUsers()
+	en := LambdaEnumerable(us).Map(func(u user) int { return u.age })
+	isTrue(t, en.Count(nil) == 5)
+	isTrue(t, en.Count(func(age int) bool { return age > 30 }) == 2)
+	isTrue(t, en.Any(func(age int) bool { return age == 40 }))
+	isFalse(t, en.Any(func(age int) bool { return age < 0 }))
+}
@@ -0,0 +1,157 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// ExpressError is raised when a lambda express does not match the signature the operator expects
+type ExpressError struct {
+	// type of the express, nil when express is nil
+	Express reflect.Type
+	// expected signature, eg: func(lambda.user) bool
+	Want string
+	// what is wrong
+	Reason string
+	// position of the express in the arguments of the operator from 1, 0 when the operator has not set it
+	arg int
+}
+
+func (e *ExpressError) Error() string {
+	return fmt.Sprintf("%s, want %s, got %v", e.Reason, e.Want, e.Express)
+}
+
+// OpError is returned by TryArray when an operator fails
+type OpError struct {
+	// operator, eg: Filter
+	Op string
+	// argument name of the operator when the argument is the cause, eg: express
+	Arg string
+	// the cause, *ExpressError when a lambda express does not match
+	Err error
+}
+
+func (e *OpError) Error() string {
+	return fmt.Sprintf("lambda: %s(%s): %s", e.Op, e.Arg, e.Err.Error())
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// RecordError is returned when a record of a CSV or JSON Lines input can not be decoded
+type RecordError struct {
+	// line of the record in the input, starts at 1
+	Line int
+	// column name of the CSV record, empty for JSON Lines
+	Column string
+	// the cause
+	Err error
+}
+
+func (e *RecordError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Err.Error())
+	}
+	return fmt.Sprintf("line %d, column %s: %s", e.Line, e.Column, e.Err.Error())
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// signature of express function, out nil means any return type
+func signature(in []reflect.Type, out []reflect.Type) string {
+	names := func(types []reflect.Type) string {
+		s := make([]string, len(types))
+		for i, t := range types {
+			s[i] = t.String()
+		}
+		return strings.Join(s, ", ")
+	}
+	switch len(out) {
+	case 0:
+		if out == nil {
+			return fmt.Sprintf("func(%s) R", names(in))
+		}
+		return fmt.Sprintf("func(%s)", names(in))
+	case 1:
+		return fmt.Sprintf("func(%s) %s", names(in), names(out))
+	default:
+		return fmt.Sprintf("func(%s) (%s)", names(in), names(out))
+	}
+}
+
+// the i'th argument of the operator from 1 is the express of the *ExpressError raised by check
+func argument(i int, check func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if ee, ok := r.(*ExpressError); ok && ee.arg == 0 {
+				ee.arg = i
+			}
+			panic(r)
+		}
+	}()
+	check()
+}
+
+// name of the failed argument in params, params are the arguments of the operator in order.
+// without the position, it is the only argument of the express type or the only argument
+func (e *ExpressError) argOf(params []param) string {
+	if e.arg > 0 && e.arg <= len(params) {
+		return params[e.arg-1].name
+	}
+	name, n := "", 0
+	for _, p := range params {
+		if reflect.TypeOf(p.value) == e.Express {
+			name, n = p.name, n+1
+		}
+	}
+	if n == 1 {
+		return name
+	}
+	if len(params) == 1 {
+		return params[0].name
+	}
+	return ""
+}
+
+// argument of operator
+type param struct {
+	name  string
+	value interface{}
+}
+
+// run fn and return the panic raised in fn as *OpError, Arg is the param of the failed express.
+// the errors and messages the operators panic with are converted, eg: *ExpressError, ErrDivideByZero, ErrOverflow,
+// runtime errors and panics of other values are raised again, eg: a nil dereference in an express
+func catch(op string, params []param, fn func()) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		var e error
+		switch v := r.(type) {
+		case runtime.Error:
+			panic(r)
+		case error:
+			e = v
+		case string:
+			e = errors.New(v)
+		default:
+			panic(r)
+		}
+		oe := &OpError{Op: op, Err: e}
+		var ee *ExpressError
+		if errors.As(e, &ee) {
+			oe.Arg = ee.argOf(params)
+		}
+		err = oe
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,1011 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// CompileExpress compiles a lambda express written as string into a func
+// which can be used anywhere Array takes an express
+// in are the parameter types of the lambda, eg: reflect.TypeOf(user{})
+//
+// the syntax is `u => body` or `(a, b) => body`, body supports
+//
+//	field access      u.Address.City, pointers are dereferenced, map[string]V is accessed by key
+//	index             u.tags[0], u.attrs["k"]
+//	literals          18, 1.5, "str", 'str', true, false, nil
+//	arithmetic        + - * / %, + also joins strings, not defined on a signed and an unsigned integer
+//	comparison        == != < <= > >=, a signed and an unsigned integer are compared by value
+//	boolean logic     && || !
+//	in list           u.name in ["Abel", "Edith"]
+//	string functions  len(s) lower(s) upper(s) trim(s) contains(s, sub) startsWith(s, prefix) endsWith(s, suffix)
+//
+// eg: arr.Filter(MustCompileExpress("u => u.age > 18 && startsWith(u.name, \"A\")", reflect.TypeOf(user{})))
+// returns *ExpressSyntaxError with position when src can not be parsed or type checked,
+// the express panics with ErrDivideByZero when an integer is divided by zero
+func CompileExpress(src string, in ...reflect.Type) (interface{}, error) {
+	p := &parser{lexer: lexer{src: src}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	params, body, err := p.lambda()
+	if err != nil {
+		return nil, err
+	}
+	if len(params) != len(in) {
+		return nil, &ExpressSyntaxError{1, fmt.Sprintf("lambda has %d parameters, want %d", len(params), len(in))}
+	}
+	c := &compiler{params: map[string]int{}, in: in}
+	for i, name := range params {
+		c.params[name] = i
+	}
+	e, err := c.compile(body)
+	if err != nil {
+		return nil, err
+	}
+	if e.t == nil {
+		return nil, &ExpressSyntaxError{body.pos, "express result can not be nil"}
+	}
+	ft := reflect.FuncOf(in, []reflect.Type{e.t}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		return []reflect.Value{export(e.eval(args), e.t)}
+	}).Interface(), nil
+}
+
+// same as CompileExpress, panic when src is invalid
+func MustCompileExpress(src string, in ...reflect.Type) interface{} {
+	fn, err := CompileExpress(src, in...)
+	if err != nil {
+		panic(err)
+	}
+	return fn
+}
+
+// ErrDivideByZero is raised by a compiled express dividing an integer by zero,
+// TryArray returns it as the Err of *OpError
+var ErrDivideByZero = errors.New("integer divide by zero")
+
+// Lambda is an express written as string, compiled by the operator against its element type,
+// eg: arr.Filter(Lambda("u => u.age > 18")) is arr.Filter(MustCompileExpress("u => u.age > 18", reflect.TypeOf(user{}))).
+// a plain string is compiled the same way by every operator but Contains, which matches it as an element.
+// the express of Sort and SortMT has two parameters, eg: "(a, b) => a.age < b.age"
+// an invalid src panics with *ExpressSyntaxError when the operator is called
+type Lambda string
+
+func (l Lambda) bind(t reflect.Type) interface{} {
+	return MustCompileExpress(string(l), t)
+}
+
+// ExpressSyntaxError is returned by CompileExpress
+type ExpressSyntaxError struct {
+	// 1-based position in src
+	Pos int
+	Msg string
+}
+
+func (e *ExpressSyntaxError) Error() string {
+	return fmt.Sprintf("express:%d: %s", e.Pos, e.Msg)
+}
+
+// lexer
+
+const (
+	tokEOF = iota
+	tokIdent
+	tokInt
+	tokFloat
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind int
+	text string
+	pos  int
+}
+
+type lexer struct {
+	src string
+	off int
+}
+
+func (l *lexer) scan() (token, error) {
+	for l.off < len(l.src) && unicode.IsSpace(rune(l.src[l.off])) {
+		l.off++
+	}
+	start := l.off
+	if l.off >= len(l.src) {
+		return token{tokEOF, "", start + 1}, nil
+	}
+	c := l.src[l.off]
+	switch {
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for l.off < len(l.src) && (l.src[l.off] == '_' || unicode.IsLetter(rune(l.src[l.off])) || unicode.IsDigit(rune(l.src[l.off]))) {
+			l.off++
+		}
+		return token{tokIdent, l.src[start:l.off], start + 1}, nil
+	case unicode.IsDigit(rune(c)):
+		kind := tokInt
+		for l.off < len(l.src) && (unicode.IsDigit(rune(l.src[l.off])) || l.src[l.off] == '.') {
+			if l.src[l.off] == '.' {
+				kind = tokFloat
+			}
+			l.off++
+		}
+		return token{kind, l.src[start:l.off], start + 1}, nil
+	case c == '"' || c == '\'':
+		l.off++
+		var b strings.Builder
+		for l.off < len(l.src) && l.src[l.off] != c {
+			if l.src[l.off] == '\\' && l.off+1 < len(l.src) {
+				l.off++
+			}
+			b.WriteByte(l.src[l.off])
+			l.off++
+		}
+		if l.off >= len(l.src) {
+			return token{}, &ExpressSyntaxError{start + 1, "string literal not terminated"}
+		}
+		l.off++
+		return token{tokString, b.String(), start + 1}, nil
+	}
+	for _, op := range []string{"=>", "==", "!=", "<=", ">=", "&&", "||"} {
+		if strings.HasPrefix(l.src[l.off:], op) {
+			l.off += 2
+			return token{tokOp, op, start + 1}, nil
+		}
+	}
+	if strings.IndexByte("<>+-*/%!()[].,", c) >= 0 {
+		l.off++
+		return token{tokOp, string(c), start + 1}, nil
+	}
+	return token{}, &ExpressSyntaxError{start + 1, fmt.Sprintf("unexpected character %q", c)}
+}
+
+// parser
+
+const (
+	nodeLiteral = iota
+	nodeParam
+	nodeField
+	nodeIndex
+	nodeCall
+	nodeUnary
+	nodeBinary
+	nodeList
+)
+
+type node struct {
+	kind int
+	pos  int
+	// operator, field, param or function name
+	name string
+	// literal value
+	value interface{}
+	args  []*node
+}
+
+type parser struct {
+	lexer
+	tok token
+}
+
+func (p *parser) next() (err error) {
+	p.tok, err = p.scan()
+	return err
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ExpressSyntaxError{p.tok.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) is(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+func (p *parser) expect(op string) error {
+	if !p.is(op) {
+		return p.errorf("expected %q, found %q", op, p.tok.text)
+	}
+	return p.next()
+}
+
+func (p *parser) ident() (string, error) {
+	if p.tok.kind != tokIdent {
+		return "", p.errorf("expected identifier, found %q", p.tok.text)
+	}
+	name := p.tok.text
+	return name, p.next()
+}
+
+func (p *parser) lambda() ([]string, *node, error) {
+	var params []string
+	if p.is("(") {
+		if err := p.next(); err != nil {
+			return nil, nil, err
+		}
+		for !p.is(")") {
+			if len(params) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, nil, err
+				}
+			}
+			name, err := p.ident()
+			if err != nil {
+				return nil, nil, err
+			}
+			params = append(params, name)
+		}
+		if err := p.next(); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		name, err := p.ident()
+		if err != nil {
+			return nil, nil, err
+		}
+		params = append(params, name)
+	}
+	if err := p.expect("=>"); err != nil {
+		return nil, nil, err
+	}
+	body, err := p.binary(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return params, body, nil
+}
+
+// precedence of binary operators
+func (p *parser) precedence() int {
+	switch {
+	case p.is("||"):
+		return 1
+	case p.is("&&"):
+		return 2
+	case p.is("=="), p.is("!="), p.is("<"), p.is("<="), p.is(">"), p.is(">="),
+		p.tok.kind == tokIdent && p.tok.text == "in":
+		return 3
+	case p.is("+"), p.is("-"):
+		return 4
+	case p.is("*"), p.is("/"), p.is("%"):
+		return 5
+	}
+	return 0
+}
+
+func (p *parser) binary(min int) (*node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for prec := p.precedence(); prec >= min; prec = p.precedence() {
+		op := p.tok
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.binary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &node{kind: nodeBinary, pos: op.pos, name: op.text, args: []*node{left, right}}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (*node, error) {
+	if p.is("!") || p.is("-") {
+		op := p.tok
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: nodeUnary, pos: op.pos, name: op.text, args: []*node{x}}, nil
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (*node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pos := p.tok.pos
+		switch {
+		case p.is("."):
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			x = &node{kind: nodeField, pos: pos, name: name, args: []*node{x}}
+		case p.is("["):
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			i, err := p.binary(1)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &node{kind: nodeIndex, pos: pos, args: []*node{x, i}}
+		default:
+			return x, nil
+		}
+	}
+}
+
+// comma separated expressions until end
+func (p *parser) list(end string) ([]*node, error) {
+	var args []*node
+	for !p.is(end) {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.binary(1)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, p.next()
+}
+
+func (p *parser) primary() (*node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokInt:
+		v, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", tok.text)
+		}
+		return &node{kind: nodeLiteral, pos: tok.pos, value: int(v)}, p.next()
+	case tokFloat:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", tok.text)
+		}
+		return &node{kind: nodeLiteral, pos: tok.pos, value: v}, p.next()
+	case tokString:
+		return &node{kind: nodeLiteral, pos: tok.pos, value: tok.text}, p.next()
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "true", "false":
+			return &node{kind: nodeLiteral, pos: tok.pos, value: tok.text == "true"}, nil
+		case "nil":
+			return &node{kind: nodeLiteral, pos: tok.pos}, nil
+		}
+		if p.is("(") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			args, err := p.list(")")
+			if err != nil {
+				return nil, err
+			}
+			return &node{kind: nodeCall, pos: tok.pos, name: tok.text, args: args}, nil
+		}
+		return &node{kind: nodeParam, pos: tok.pos, name: tok.text}, nil
+	case tokOp:
+		if p.is("(") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			x, err := p.binary(1)
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		}
+		if p.is("[") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			args, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+			return &node{kind: nodeList, pos: tok.pos, args: args}, nil
+		}
+	}
+	if tok.kind == tokEOF {
+		return nil, p.errorf("unexpected end of express")
+	}
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+// compiler
+
+var (
+	boolType    = reflect.TypeOf(true)
+	stringType  = reflect.TypeOf("")
+	intType     = reflect.TypeOf(0)
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	uint64Type  = reflect.TypeOf(uint64(0))
+)
+
+// compiled express
+type compiled struct {
+	eval func(args []reflect.Value) reflect.Value
+	// static type, nil for nil literal
+	t reflect.Type
+	// number literal, adopts the type of the other operand
+	untyped bool
+}
+
+// convert the result to t
+func (e compiled) convert(t reflect.Type) compiled {
+	if e.t == t {
+		return compiled{e.eval, t, false}
+	}
+	eval := e.eval
+	return compiled{func(args []reflect.Value) reflect.Value {
+		return eval(args).Convert(t)
+	}, t, false}
+}
+
+type compiler struct {
+	params map[string]int
+	in     []reflect.Type
+}
+
+func errorAt(n *node, format string, args ...interface{}) error {
+	return &ExpressSyntaxError{n.pos, fmt.Sprintf(format, args...)}
+}
+
+func isInt(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+func isNumber(t reflect.Type) bool {
+	return t != nil && (isInt(t) || isUint(t) || isFloat(t))
+}
+
+func isString(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.String
+}
+
+func isBool(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Bool
+}
+
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return true
+	}
+	return false
+}
+
+// v as a value of t, the zero value of t when v is invalid.
+// the values are readable, fields are read by fieldStep which makes unexported fields accessible
+func export(v reflect.Value, t reflect.Type) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Zero(t)
+	}
+	return v.Convert(t)
+}
+
+func (c *compiler) compile(n *node) (compiled, error) {
+	switch n.kind {
+	case nodeLiteral:
+		if n.value == nil {
+			return compiled{func([]reflect.Value) reflect.Value { return reflect.Value{} }, nil, false}, nil
+		}
+		v := reflect.ValueOf(n.value)
+		return compiled{func([]reflect.Value) reflect.Value { return v }, v.Type(), isNumber(v.Type())}, nil
+	case nodeParam:
+		i, ok := c.params[n.name]
+		if !ok {
+			return compiled{}, errorAt(n, "undefined: %s", n.name)
+		}
+		return compiled{func(args []reflect.Value) reflect.Value { return args[i] }, c.in[i], false}, nil
+	case nodeField:
+		return c.field(n)
+	case nodeIndex:
+		return c.index(n)
+	case nodeCall:
+		return c.call(n)
+	case nodeUnary:
+		return c.unary(n)
+	case nodeList:
+		return compiled{}, errorAt(n, "list is only allowed after in")
+	}
+	return c.binary(n)
+}
+
+// dereference pointers, nil pointer is returned as invalid value
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// field of struct or key of map[string]V, nil pointer results zero value
+func (c *compiler) field(n *node) (compiled, error) {
+	x, err := c.compile(n.args[0])
+	if err != nil {
+		return x, err
+	}
+	if x.t == nil {
+		return x, errorAt(n, "nil has no field %s", n.name)
+	}
+	t := derefType(x.t)
+	switch t.Kind() {
+	case reflect.Struct:
+		f, ok := t.FieldByName(n.name)
+		if !ok {
+			return x, errorAt(n, "%s has no field %s", x.t.String(), n.name)
+		}
+		step := fieldStep(t, f.Index)
+		return compiled{func(args []reflect.Value) reflect.Value {
+			v := deref(x.eval(args))
+			if v.IsValid() {
+				v = step(v)
+			}
+			if !v.IsValid() {
+				return reflect.Zero(f.Type)
+			}
+			return v
+		}, f.Type, false}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return x, errorAt(n, "%s has no field %s", x.t.String(), n.name)
+		}
+		key := reflect.ValueOf(n.name).Convert(t.Key())
+		return compiled{func(args []reflect.Value) reflect.Value {
+			v := deref(x.eval(args))
+			if !v.IsValid() || v.IsNil() {
+				return reflect.Zero(t.Elem())
+			}
+			if e := v.MapIndex(key); e.IsValid() {
+				return e
+			}
+			return reflect.Zero(t.Elem())
+		}, t.Elem(), false}, nil
+	}
+	return x, errorAt(n, "%s has no field %s", x.t.String(), n.name)
+}
+
+// index of slice, array, string or map, out of range or missing key results zero value
+func (c *compiler) index(n *node) (compiled, error) {
+	x, err := c.compile(n.args[0])
+	if err != nil {
+		return x, err
+	}
+	i, err := c.compile(n.args[1])
+	if err != nil {
+		return x, err
+	}
+	if x.t == nil {
+		return x, errorAt(n, "nil can not be indexed")
+	}
+	t := derefType(x.t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !isInt(i.t) {
+			return x, errorAt(n.args[1], "index must be integer, not %v", i.t)
+		}
+		return compiled{func(args []reflect.Value) reflect.Value {
+			v, k := deref(x.eval(args)), int(i.eval(args).Int())
+			if !v.IsValid() || k < 0 || k >= v.Len() {
+				return reflect.Zero(t.Elem())
+			}
+			return v.Index(k)
+		}, t.Elem(), false}, nil
+	case reflect.Map:
+		if i.t == nil || !i.t.ConvertibleTo(t.Key()) {
+			return x, errorAt(n.args[1], "key must be %s, not %v", t.Key().String(), i.t)
+		}
+		return compiled{func(args []reflect.Value) reflect.Value {
+			v := deref(x.eval(args))
+			if !v.IsValid() || v.IsNil() {
+				return reflect.Zero(t.Elem())
+			}
+			if e := v.MapIndex(i.eval(args).Convert(t.Key())); e.IsValid() {
+				return e
+			}
+			return reflect.Zero(t.Elem())
+		}, t.Elem(), false}, nil
+	}
+	return x, errorAt(n, "%s can not be indexed", x.t.String())
+}
+
+// string functions
+var expressFuncs = map[string]struct {
+	in  int
+	out reflect.Type
+	fn  func(s []string) interface{}
+}{
+	"len":        {1, intType, func(s []string) interface{} { return len(s[0]) }},
+	"lower":      {1, stringType, func(s []string) interface{} { return strings.ToLower(s[0]) }},
+	"upper":      {1, stringType, func(s []string) interface{} { return strings.ToUpper(s[0]) }},
+	"trim":       {1, stringType, func(s []string) interface{} { return strings.TrimSpace(s[0]) }},
+	"contains":   {2, boolType, func(s []string) interface{} { return strings.Contains(s[0], s[1]) }},
+	"startsWith": {2, boolType, func(s []string) interface{} { return strings.HasPrefix(s[0], s[1]) }},
+	"endsWith":   {2, boolType, func(s []string) interface{} { return strings.HasSuffix(s[0], s[1]) }},
+}
+
+func (c *compiler) call(n *node) (compiled, error) {
+	f, ok := expressFuncs[n.name]
+	if !ok {
+		return compiled{}, errorAt(n, "undefined function: %s", n.name)
+	}
+	if len(n.args) != f.in {
+		return compiled{}, errorAt(n, "%s takes %d arguments, not %d", n.name, f.in, len(n.args))
+	}
+	args := make([]compiled, len(n.args))
+	for i, a := range n.args {
+		e, err := c.compile(a)
+		if err != nil {
+			return e, err
+		}
+		if !isString(e.t) {
+			return e, errorAt(a, "argument of %s must be string, not %v", n.name, e.t)
+		}
+		args[i] = e
+	}
+	return compiled{func(values []reflect.Value) reflect.Value {
+		s := make([]string, len(args))
+		for i, a := range args {
+			s[i] = a.eval(values).String()
+		}
+		return reflect.ValueOf(f.fn(s))
+	}, f.out, false}, nil
+}
+
+func (c *compiler) unary(n *node) (compiled, error) {
+	x, err := c.compile(n.args[0])
+	if err != nil {
+		return x, err
+	}
+	if n.name == "!" {
+		if !isBool(x.t) {
+			return x, errorAt(n, "operator ! not defined on %v", x.t)
+		}
+		return compiled{func(args []reflect.Value) reflect.Value {
+			return reflect.ValueOf(!x.eval(args).Bool())
+		}, boolType, false}, nil
+	}
+	if !isNumber(x.t) || isUint(x.t) {
+		return x, errorAt(n, "operator - not defined on %v", x.t)
+	}
+	t := x.t
+	return compiled{func(args []reflect.Value) reflect.Value {
+		v := x.eval(args)
+		ret := reflect.New(t).Elem()
+		if isFloat(t) {
+			ret.SetFloat(-v.Float())
+		} else {
+			ret.SetInt(-v.Int())
+		}
+		return ret
+	}, t, x.untyped}, nil
+}
+
+// the common type of number operands
+func numberType(a, b compiled) (reflect.Type, bool) {
+	switch {
+	case a.untyped && b.untyped:
+		if isFloat(a.t) || isFloat(b.t) {
+			return float64Type, true
+		}
+		return intType, true
+	case a.untyped && !(isFloat(a.t) && !isFloat(b.t)):
+		return b.t, false
+	case b.untyped && !(isFloat(b.t) && !isFloat(a.t)):
+		return a.t, false
+	case a.t == b.t:
+		return a.t, false
+	case isFloat(a.t) || isFloat(b.t):
+		return float64Type, false
+	case isUint(a.t) && isUint(b.t):
+		return uint64Type, false
+	}
+	return int64Type, false
+}
+
+// typed integer operands of different signedness, they have no common integer type
+func mixedSign(a, b compiled) bool {
+	return !a.untyped && !b.untyped && (isInt(a.t) && isUint(b.t) || isUint(a.t) && isInt(b.t))
+}
+
+// compare a signed and an unsigned integer by the sign first, so no value wraps around
+func compareMixed(a, b reflect.Value) int {
+	if isUint(a.Type()) {
+		return -compareMixed(b, a)
+	}
+	if a.Int() < 0 {
+		return -1
+	}
+	return compareOrdered(uint64(a.Int()), b.Uint())
+}
+
+// the untyped integer operands fit in the integer type t, eg: -1 does not fit in uint
+func representable(n *node, t reflect.Type, operands ...compiled) error {
+	for _, e := range operands {
+		if !e.untyped || e.t == t || !isInt(e.t) {
+			continue
+		}
+		v := e.eval(nil).Int()
+		switch {
+		case isUint(t) && (v < 0 || reflect.Zero(t).OverflowUint(uint64(v))),
+			isInt(t) && reflect.Zero(t).OverflowInt(v):
+			return errorAt(n, "constant %d overflows %s", v, t.String())
+		}
+	}
+	return nil
+}
+
+func (c *compiler) binary(n *node) (compiled, error) {
+	x, err := c.compile(n.args[0])
+	if err != nil {
+		return x, err
+	}
+	if n.name == "in" {
+		return c.member(n, x)
+	}
+	y, err := c.compile(n.args[1])
+	if err != nil {
+		return y, err
+	}
+	switch n.name {
+	case "&&", "||":
+		if !isBool(x.t) || !isBool(y.t) {
+			return x, errorAt(n, "operator %s not defined on %v and %v", n.name, x.t, y.t)
+		}
+		and := n.name == "&&"
+		return compiled{func(args []reflect.Value) reflect.Value {
+			if x.eval(args).Bool() != and {
+				return reflect.ValueOf(!and)
+			}
+			return reflect.ValueOf(y.eval(args).Bool())
+		}, boolType, false}, nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		cmp, err := comparison(n, x, y)
+		if err != nil {
+			return x, err
+		}
+		op := n.name
+		return compiled{func(args []reflect.Value) reflect.Value {
+			r := cmp(args)
+			if r == unordered {
+				return reflect.ValueOf(op == "!=")
+			}
+			switch op {
+			case "==":
+				return reflect.ValueOf(r == 0)
+			case "!=":
+				return reflect.ValueOf(r != 0)
+			case "<":
+				return reflect.ValueOf(r < 0)
+			case "<=":
+				return reflect.ValueOf(r <= 0)
+			case ">":
+				return reflect.ValueOf(r > 0)
+			}
+			return reflect.ValueOf(r >= 0)
+		}, boolType, false}, nil
+	}
+	// arithmetic
+	if n.name == "+" && isString(x.t) && isString(y.t) {
+		return compiled{func(args []reflect.Value) reflect.Value {
+			return reflect.ValueOf(x.eval(args).String() + y.eval(args).String())
+		}, stringType, false}, nil
+	}
+	if !isNumber(x.t) || !isNumber(y.t) {
+		return x, errorAt(n, "operator %s not defined on %v and %v", n.name, x.t, y.t)
+	}
+	if mixedSign(x, y) {
+		return x, errorAt(n, "operator %s on mismatched types %v and %v", n.name, x.t, y.t)
+	}
+	t, untyped := numberType(x, y)
+	if n.name == "%" && isFloat(t) {
+		return x, errorAt(n, "operator %% not defined on %s", t.String())
+	}
+	if err := representable(n, t, x, y); err != nil {
+		return x, err
+	}
+	a, b, op := x.convert(t), y.convert(t), n.name
+	if (op == "/" || op == "%") && !isFloat(t) && y.untyped && b.eval(nil).IsZero() {
+		return x, errorAt(n.args[1], "integer division by zero")
+	}
+	return compiled{func(args []reflect.Value) reflect.Value {
+		va, vb := a.eval(args), b.eval(args)
+		ret := reflect.New(t).Elem()
+		switch {
+		case isFloat(t):
+			ret.SetFloat(arithFloat(op, va.Float(), vb.Float()))
+		case isUint(t):
+			ret.SetUint(arithUint(op, va.Uint(), vb.Uint()))
+		default:
+			ret.SetInt(arithInt(op, va.Int(), vb.Int()))
+		}
+		return ret
+	}, t, untyped}, nil
+}
+
+func arithFloat(op string, a, b float64) float64 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	}
+	return a / b
+}
+
+func arithInt(op string, a, b int64) int64 {
+	if b == 0 && (op == "/" || op == "%") {
+		panic(ErrDivideByZero)
+	}
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "%":
+		return a % b
+	}
+	return a / b
+}
+
+func arithUint(op string, a, b uint64) uint64 {
+	if b == 0 && (op == "/" || op == "%") {
+		panic(ErrDivideByZero)
+	}
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "%":
+		return a % b
+	}
+	return a / b
+}
+
+// comparison result of NaN, compiled expressions compare floats by IEEE 754, so only != is true
+const unordered = 2
+
+// three-way comparison of x and y, unordered when a float operand is NaN
+func comparison(n *node, x, y compiled) (func(args []reflect.Value) int, error) {
+	equality := n.name == "==" || n.name == "!=" || n.name == "in"
+	switch {
+	case mixedSign(x, y):
+		return func(args []reflect.Value) int {
+			return compareMixed(x.eval(args), y.eval(args))
+		}, nil
+	case isNumber(x.t) && isNumber(y.t):
+		t, _ := numberType(x, y)
+		if err := representable(n, t, x, y); err != nil {
+			return nil, err
+		}
+		a, b := x.convert(t), y.convert(t)
+		return func(args []reflect.Value) int {
+			va, vb := a.eval(args), b.eval(args)
+			switch {
+			case isFloat(t):
+				fa, fb := va.Float(), vb.Float()
+				if math.IsNaN(fa) || math.IsNaN(fb) {
+					return unordered
+				}
+				return compareOrdered(fa, fb)
+			case isUint(t):
+				return compareOrdered(va.Uint(), vb.Uint())
+			}
+			return compareOrdered(va.Int(), vb.Int())
+		}, nil
+	case isString(x.t) && isString(y.t):
+		return func(args []reflect.Value) int {
+			return strings.Compare(x.eval(args).String(), y.eval(args).String())
+		}, nil
+	case isBool(x.t) && isBool(y.t) && equality:
+		return func(args []reflect.Value) int {
+			if x.eval(args).Bool() == y.eval(args).Bool() {
+				return 0
+			}
+			return 1
+		}, nil
+	case equality && (x.t == nil && y.t != nil && nullable(y.t) || y.t == nil && x.t != nil && nullable(x.t)):
+		v := x
+		if x.t == nil {
+			v = y
+		}
+		return func(args []reflect.Value) int {
+			if r := v.eval(args); !r.IsValid() || r.IsNil() {
+				return 0
+			}
+			return 1
+		}, nil
+	case equality && x.t != nil && x.t == y.t && x.t.Comparable():
+		return func(args []reflect.Value) int {
+			if x.eval(args).Interface() == y.eval(args).Interface() {
+				return 0
+			}
+			return 1
+		}, nil
+	}
+	return nil, errorAt(n, "operator %s not defined on %v and %v", n.name, x.t, y.t)
+}
+
+// x in [a, b, c]
+func (c *compiler) member(n *node, x compiled) (compiled, error) {
+	list := n.args[1]
+	if list.kind != nodeList {
+		return x, errorAt(list, "in must be followed by a list, eg: [1, 2]")
+	}
+	cmps := make([]func(args []reflect.Value) int, len(list.args))
+	for i, a := range list.args {
+		y, err := c.compile(a)
+		if err != nil {
+			return y, err
+		}
+		if cmps[i], err = comparison(n, x, y); err != nil {
+			return y, err
+		}
+	}
+	return compiled{func(args []reflect.Value) reflect.Value {
+		for _, cmp := range cmps {
+			if cmp(args) == 0 {
+				return reflect.ValueOf(true)
+			}
+		}
+		return reflect.ValueOf(false)
+	}, boolType, false}, nil
+}
@@ -0,0 +1,175 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string
+}
+
+type member struct {
+	Name    string
+	Age     int
+	Score   float64
+	Address *address
+	Tags    []string
+	Attrs   map[string]string
+}
+
+func makeMembers() []member {
+	return []member{
+		{"Abraham", 20, 1.5, &address{"Paris"}, []string{"a"}, map[string]string{"level": "gold"}},
+		{"Edith", 25, 3, nil, nil, nil},
+		{"Charles", 40, 2.5, &address{"Rome"}, []string{"b", "c"}, map[string]string{"level": "silver"}},
+	}
+}
+
+func TestCompileExpress(t *testing.T) {
+	defer report(t, time.Now())
+	mt := reflect.TypeOf(member{})
+	arr := LambdaArray(makeMembers())
+	names := func(express string) string {
+		return fmt.Sprint(arr.Filter(MustCompileExpress(express, mt)).Map(func(m member) string { return m.Name }).Pointer())
+	}
+	isTrue(t, names("m => m.Age > 21") == "[Edith Charles]")
+	isTrue(t, names("m => m.Age >= 20 && !(m.Age % 2 == 0)") == "[Edith]")
+	isTrue(t, names("m => m.Address.City == 'Rome' || m.Score * 2 > 5.5") == "[Edith Charles]")
+	isTrue(t, names("m => m.Address == nil") == "[Edith]")
+	isTrue(t, names(`m => lower(m.Name) in ["edith", "charles"]`) == "[Edith Charles]")
+	isTrue(t, names(`m => startsWith(m.Name, "A") && len(m.Name) == 7`) == "[Abraham]")
+	isTrue(t, names(`m => m.Tags[1] == "c" || m.Attrs.level == "gold"`) == "[Abraham Charles]")
+	isTrue(t, names(`m => m.Attrs["level"] != ""`) == "[Abraham Charles]")
+
+	// unexported fields and result typed by field
+	us := LambdaArray(makeUserArray())
+	isTrue(t, us.Sum(MustCompileExpress("u => u.age", reflect.TypeOf(user{}))) == us.Sum(func(u user) int { return u.age }))
+	isTrue(t, us.Sum(MustCompileExpress("u => u.age * 2 - 1", reflect.TypeOf(user{}))).(int) == count*count)
+
+	ints := LambdaArray([]int{1, 3, 8, 6, 12, 5, 9}).Sort(MustCompileExpress("(a, b) => a < b", intType, intType))
+	isTrue(t, fmt.Sprint(ints.Pointer()) == "[1 3 5 6 8 9 12]")
+}
+
+func TestLambda(t *testing.T) {
+	defer report(t, time.Now())
+	ints := LambdaArray([]int{1, 3, 8, 6, 12, 5, 9})
+	isTrue(t, fmt.Sprint(ints.Filter("x => x > 5").Pointer()) == "[8 6 12 9]")
+	isTrue(t, fmt.Sprint(ints.Map(Lambda("x => x * 2")).Take(0, 3).Pointer()) == "[2 6 16]")
+	isTrue(t, ints.Count("x => x % 2 == 0") == 3)
+	isTrue(t, fmt.Sprint(ints.Sort("(a, b) => a < b").Pointer()) == "[1 3 5 6 8 9 12]")
+	isTrue(t, fmt.Sprint(ints.SortMT("(a, b) => a < b").Pointer()) == fmt.Sprint(ints.SortMT(func(a, b int) bool { return a < b }).Pointer()))
+	isTrue(t, ints.Contains(Lambda("x => x > 10")))
+
+	arr := LambdaArray(makeMembers())
+	isTrue(t, arr.Sum("m => m.Age") == 85)
+	isTrue(t, fmt.Sprint(arr.OrderByDescending("m => m.Score").Map("m => m.Name").Pointer()) == "[Edith Charles Abraham]")
+	isTrue(t, arr.GroupBy("m => m.Age > 21").Count(nil) == 2)
+	isTrue(t, arr.AsEnumerable().Filter("m => m.Address != nil").Count(nil) == 2)
+
+	// a plain string is matched as an element by Contains
+	isTrue(t, LambdaArray([]string{"x => x"}).Contains("x => x"))
+	isFalse(t, LambdaArray([]string{"a"}).Contains(Lambda("s => s == 'b'")))
+
+	_, err := TryLambdaArray([]int{1}).Filter("x => x.Age > 1").Count(nil)
+	var se *ExpressSyntaxError
+	isTrue(t, errors.As(err, &se))
+}
+
+func TestCompileExpress_NaN(t *testing.T) {
+	defer report(t, time.Now())
+	ft := reflect.TypeOf(float64(0))
+	nan := LambdaArray([]float64{math.NaN()})
+	for src, want := range map[string]bool{
+		"x => x < 5": false, "x => x <= 5": false, "x => x > 5": false, "x => x >= 5": false,
+		"x => x == x": false, "x => x != x": true, "x => x in [1, 2]": false,
+	} {
+		if got := nan.Any(MustCompileExpress(src, ft)); got != want {
+			t.Errorf("%s of NaN: %v, want %v", src, got, want)
+		}
+	}
+	isTrue(t, LambdaArray([]float64{1, 2}).Count(MustCompileExpress("x => x <= 1.5", ft)) == 1)
+}
+
+func TestCompileExpress_DivideByZero(t *testing.T) {
+	defer report(t, time.Now())
+	for _, src := range []string{"x => x / 0", "x => x % (2 - 2)", "x => 1 + x / 0"} {
+		_, err := CompileExpress(src, intType)
+		var se *ExpressSyntaxError
+		isTrue(t, errors.As(err, &se))
+	}
+	_, err := CompileExpress("x => x / 0.0", reflect.TypeOf(float64(0)))
+	isTrue(t, err == nil)
+
+	_, err = TryLambdaArray([]int{2, 0}).Map(MustCompileExpress("x => 10 / x", intType)).Pointer()
+	var oe *OpError
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Map" && errors.Is(err, ErrDivideByZero))
+	_, err = TryLambdaArray([]uint{0}).Map(MustCompileExpress("x => 10 % x", reflect.TypeOf(uint(0)))).Pointer()
+	isTrue(t, errors.Is(err, ErrDivideByZero))
+}
+
+func TestCompileExpress_Unsigned(t *testing.T) {
+	defer report(t, time.Now())
+	type counter struct {
+		Count uint
+		Small int8
+	}
+	ct := reflect.TypeOf(counter{})
+	for _, src := range []string{"c => c.Count > -1", "c => c.Count + -1", "c => c.Count in [1, -2]", "c => c.Small < 300"} {
+		_, err := CompileExpress(src, ct)
+		var se *ExpressSyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("%s: %v, want *ExpressSyntaxError", src, err)
+		}
+	}
+	arr := LambdaArray([]counter{{0, 1}, {2, -1}})
+	isTrue(t, arr.Count(MustCompileExpress("c => c.Count > 1 - 1", ct)) == 1)
+	isTrue(t, arr.Count(MustCompileExpress("c => c.Small > -1", ct)) == 1)
+
+	// mixed signedness compares by the sign first and is not defined for arithmetic
+	type pair struct {
+		A int64
+		B uint64
+	}
+	pairs := LambdaArray([]pair{{-1, 1 << 63}, {5, 3}, {3, 3}, {-1, 0}})
+	pt := reflect.TypeOf(pair{})
+	isTrue(t, pairs.Count(MustCompileExpress("r => r.A < r.B", pt)) == 2)
+	isTrue(t, pairs.Count(MustCompileExpress("r => r.B > r.A", pt)) == 2)
+	isTrue(t, pairs.Count(MustCompileExpress("r => r.A == r.B", pt)) == 1)
+	isTrue(t, pairs.Count(MustCompileExpress("r => r.A in [r.B, 5]", pt)) == 2)
+	_, err := CompileExpress("r => r.A + r.B", pt)
+	isTrue(t, err != nil && strings.Contains(err.Error(), "mismatched types int64 and uint64"))
+}
+
+func TestCompileExpress_Error(t *testing.T) {
+	defer report(t, time.Now())
+	mt := reflect.TypeOf(member{})
+	cases := map[string]int{
+		"m => m.Agee > 1":      7,
+		"m => m.Age > 'a'":     12,
+		"m => m.Age >":         13,
+		"m => x.Age":           6,
+		"m => upper(m.Age)":    13,
+		"m => m.Age in 1":      15,
+		"m => \"abc":           6,
+		"(a, b) => a":          1,
+		"m => m.Name && true":  13,
+		"m => m.Name # 1":      13,
+		"m => nil":             6,
+		"m => foo(m.Name)":     6,
+		"m => m.Tags['x']":     13,
+		"m => m.Score % 2 > 0": 14,
+	}
+	for src, pos := range cases {
+		_, err := CompileExpress(src, mt)
+		var se *ExpressSyntaxError
+		if !errors.As(err, &se) || se.Pos != pos {
+			t.Errorf("%s: %v, want position %d", src, err, pos)
+		}
+	}
+}
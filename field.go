@@ -0,0 +1,327 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Selector selects a field of the elements by a path, see Field
+type Selector struct {
+	// field path, eg: Address.City
+	path string
+	// path segments
+	names []string
+}
+
+// Field makes the selector of the field at path, eg: Field("Address.City"),
+// it is accepted by the operators anywhere an express is accepted:
+// as func(ele T) F for Map, Sum, Max, OrderBy, GroupBy, etc,
+// as func(ele T) bool for Filter, Any, Count, etc. when F is bool,
+// and as the func(a, b T) bool of Sort comparing a.F < b.F.
+// pointers are dereferenced, a nil pointer or a missing map key selects the zero value of F.
+// the path is resolved once for an element type and cached,
+// an unknown field panics with *ExpressError before any element is selected
+func Field(path string) *Selector {
+	names := strings.Split(path, ".")
+	for _, name := range names {
+		if name == "" {
+			panic(fmt.Sprintf("field path %q has an empty name", path))
+		}
+	}
+	return &Selector{path, names}
+}
+
+// FieldOf makes the selector of the field at path and resolves it for the type of sample,
+// the error is *ExpressError when the path is unknown in the type
+func FieldOf(sample interface{}, path string) (s *Selector, err error) {
+	err = catch("Field", nil, func() {
+		s = Field(path)
+		s.resolve(reflect.TypeOf(sample))
+	})
+	if err != nil {
+		return nil, err.(*OpError).Err
+	}
+	return s, nil
+}
+
+func (s *Selector) String() string {
+	return s.path
+}
+
+// selection of a field path in an element type
+type _field struct {
+	// field type
+	out reflect.Type
+	// the field of element, invalid when a pointer on the path is nil or a map key is missing
+	get func(v reflect.Value) reflect.Value
+}
+
+type fieldKey struct {
+	t    reflect.Type
+	path string
+}
+
+var fields sync.Map
+
+// resolve the path in the element type t, cached by t and path
+func (s *Selector) resolve(t reflect.Type) *_field {
+	key := fieldKey{t, s.path}
+	if f, ok := fields.Load(key); ok {
+		return f.(*_field)
+	}
+	out, steps := t, make([]func(v reflect.Value) reflect.Value, 0, len(s.names))
+	for i, name := range s.names {
+		if out.Kind() == reflect.Ptr {
+			out = derefType(out)
+			steps = append(steps, deref)
+		}
+		var step func(v reflect.Value) reflect.Value
+		switch out.Kind() {
+		case reflect.Struct:
+			sf, ok := out.FieldByName(name)
+			if !ok {
+				s.fail(t, fmt.Sprintf("%s has no field %s", out.String(), name))
+			}
+			out, step = sf.Type, fieldStep(out, sf.Index)
+		case reflect.Map:
+			k, err := mapKey(out.Key(), name)
+			if err != nil {
+				s.fail(t, fmt.Sprintf("%s is not a key of %s", name, out.String()))
+			}
+			out, step = out.Elem(), func(v reflect.Value) reflect.Value {
+				return v.MapIndex(k)
+			}
+		case reflect.Interface:
+			// the rest of the path is resolved by the dynamic type of each element
+			rest := &Selector{strings.Join(s.names[i:], "."), s.names[i:]}
+			out, step = anyType, func(v reflect.Value) reflect.Value {
+				if v.IsNil() {
+					return reflect.Value{}
+				}
+				e := v.Elem()
+				return rest.resolve(e.Type()).get(e)
+			}
+			steps = append(steps, step)
+			return s.store(key, out, steps)
+		default:
+			s.fail(t, fmt.Sprintf("%s of %s has no field %s", strings.Join(s.names[:i], "."), out.String(), name))
+		}
+		steps = append(steps, step)
+	}
+	return s.store(key, out, steps)
+}
+
+func (s *Selector) store(key fieldKey, out reflect.Type, steps []func(v reflect.Value) reflect.Value) *_field {
+	f := &_field{out, func(v reflect.Value) reflect.Value {
+		for _, step := range steps {
+			if v = step(v); !v.IsValid() {
+				return v
+			}
+		}
+		return v
+	}}
+	actual, _ := fields.LoadOrStore(key, f)
+	return actual.(*_field)
+}
+
+func (s *Selector) fail(t reflect.Type, reason string) {
+	panic(&ExpressError{Express: reflect.TypeOf(s), Want: fmt.Sprintf("field %s of %s", s.path, t.String()), Reason: reason})
+}
+
+// the field at index of struct t, invalid when an embedded pointer is nil.
+// a field read from an unexported field is made accessible so it can be returned by an express,
+// this is how Field, CompileExpress and the lambda tags read unexported fields
+func fieldStep(t reflect.Type, index []int) func(v reflect.Value) reflect.Value {
+	exported := true
+	for i := range index {
+		if t.FieldByIndex(index[:i+1]).PkgPath != "" {
+			exported = false
+		}
+	}
+	return func(v reflect.Value) reflect.Value {
+		if !exported {
+			v = addressable(v)
+		}
+		for _, i := range index {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}
+				}
+				v = v.Elem()
+			}
+			v = v.Field(i)
+		}
+		if exported {
+			return v
+		}
+		return accessible(v)
+	}
+}
+
+// addressable copy of v when v is not addressable, v must not be read from an unexported field
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// v read from an unexported field made accessible by Interface, v must be addressable
+func accessible(v reflect.Value) reflect.Value {
+	if v.CanInterface() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// the map key of name, string and integer keys are supported
+func mapKey(t reflect.Type, name string) (reflect.Value, error) {
+	k := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		k.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(name, 10, t.Bits())
+		if err != nil {
+			return k, err
+		}
+		k.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(name, 10, t.Bits())
+		if err != nil {
+			return k, err
+		}
+		k.SetUint(u)
+	default:
+		return k, fmt.Errorf("key type %s is not supported", t.String())
+	}
+	return k, nil
+}
+
+// func(ele T) F selecting the field of elements of t
+func (s *Selector) bind(t reflect.Type) interface{} {
+	f := s.resolve(t)
+	ft := reflect.FuncOf([]reflect.Type{t}, []reflect.Type{f.out}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		v := f.get(args[0])
+		if !v.IsValid() {
+			v = reflect.Zero(f.out)
+		}
+		return []reflect.Value{v}
+	}).Interface()
+}
+
+// func(a, b T) bool of the elements of t, true when the field of a is less than the field of b
+func (s *Selector) less(t reflect.Type) interface{} {
+	f := s.resolve(t)
+	if !orderedField(f.out) && f.out != anyType {
+		s.fail(t, fmt.Sprintf("field type %s is not ordered", f.out.String()))
+	}
+	get := func(v reflect.Value) interface{} {
+		if v = f.get(v); !v.IsValid() {
+			return reflect.Zero(f.out).Interface()
+		}
+		return v.Interface()
+	}
+	ft := reflect.FuncOf([]reflect.Type{t, t}, []reflect.Type{reflect.TypeOf(true)}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		tor, err := BasicComparator(get(args[0]))
+		if err != nil {
+			panic(err)
+		}
+		return []reflect.Value{reflect.ValueOf(tor.CompareTo(get(args[1])) < 0)}
+	}).Interface()
+}
+
+// binder is an express made for the element type by the operator, eg: Field, JSONPath, Lambda
+type binder interface {
+	// func(ele T) R of the elements of t
+	bind(t reflect.Type) interface{}
+}
+
+// lesser is a binder ordering the elements by the operator
+type lesser interface {
+	// func(a, b T) bool of the elements of t
+	less(t reflect.Type) interface{}
+}
+
+// the express of elements of t, a binder is bound to t, a string is compiled as Lambda
+// and the other express is returned as it is
+func bindExpress(express interface{}, t reflect.Type) interface{} {
+	switch e := express.(type) {
+	case binder:
+		return e.bind(t)
+	case string:
+		return Lambda(e).bind(t)
+	}
+	return express
+}
+
+// the express of two elements of t, eg: the fold of Reduce, a string or Lambda is compiled with (t, t),
+// see bindExpress
+func bindExpress2(express interface{}, t reflect.Type) interface{} {
+	switch e := express.(type) {
+	case Lambda:
+		return MustCompileExpress(string(e), t, t)
+	case string:
+		return MustCompileExpress(e, t, t)
+	}
+	return bindExpress(express, t)
+}
+
+// the express of the entries of a map[k]v, eg: the predicate of Dictionary.Filter,
+// a string or Lambda is compiled with (k, v) and a binder is bound to v, ignoring the key
+func bindEntry(express interface{}, k, v reflect.Type) interface{} {
+	switch e := express.(type) {
+	case Lambda:
+		return MustCompileExpress(string(e), k, v)
+	case string:
+		return MustCompileExpress(e, k, v)
+	case binder:
+		fn := reflect.ValueOf(e.bind(v))
+		ft := reflect.FuncOf([]reflect.Type{k, v}, []reflect.Type{fn.Type().Out(0)}, false)
+		return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+			return fn.Call(args[1:])
+		}).Interface()
+	}
+	return express
+}
+
+// the express ordering two elements of t, see bindExpress
+// express nil orders the elements by BasicComparator when t is ordered
+func bindLess(express interface{}, t reflect.Type) interface{} {
+	switch e := express.(type) {
+	case lesser:
+		return e.less(t)
+	case Lambda:
+		return MustCompileExpress(string(e), t, t)
+	case string:
+		return MustCompileExpress(e, t, t)
+	}
+	if express == nil && orderedField(t) {
+		return natural(t, -1)
+	}
+	return express
+}
+
+// func(a, b T) bool calling less(b, a)
+func swap(less interface{}) interface{} {
+	fn := reflect.ValueOf(less)
+	return reflect.MakeFunc(fn.Type(), func(args []reflect.Value) []reflect.Value {
+		return fn.Call([]reflect.Value{args[1], args[0]})
+	}).Interface()
+}
+
+// func(a, b T) bool of the elements of t, true when BasicComparator compares a to b as sign
+func natural(t reflect.Type, sign int) interface{} {
+	ft := reflect.FuncOf([]reflect.Type{t, t}, []reflect.Type{reflect.TypeOf(true)}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf(compareField(args[0], args[1])*sign > 0)}
+	}).Interface()
+}
@@ -0,0 +1,115 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type profile struct {
+	member
+	active bool
+	meta   interface{}
+}
+
+func TestField(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeMembers())
+	isTrue(t, fmt.Sprint(arr.Map(Field("Name")).Pointer()) == "[Abraham Edith Charles]")
+	// nil pointer and missing key select the zero value
+	isTrue(t, fmt.Sprint(arr.Map(Field("Address.City")).Pointer()) == "[Paris  Rome]")
+	isTrue(t, fmt.Sprint(arr.Map(Field("Attrs.level")).Pointer()) == "[gold  silver]")
+	isTrue(t, arr.Sum(Field("Age")) == 85)
+	isTrue(t, arr.Average(Field("Score")) == 7.0/3)
+	isTrue(t, arr.Max(Field("Score")).(member).Name == "Edith")
+	isTrue(t, arr.Min(Field("Address.City")).(member).Name == "Edith")
+	isTrue(t, fmt.Sprint(arr.OrderByDescending(Field("Age")).Map(Field("Name")).Pointer()) == "[Charles Edith Abraham]")
+	isTrue(t, fmt.Sprint(arr.Sort(Field("Name")).Map(Field("Name")).Pointer()) == "[Abraham Charles Edith]")
+	isTrue(t, fmt.Sprint(arr.SortMT(Field("Score")).Map(Field("Name")).Pointer()) == "[Abraham Charles Edith]")
+	isTrue(t, arr.GroupBy(Field("Address.City")).Count(nil) == 3)
+	isTrue(t, arr.DistinctBy(Field("Attrs.level")).Count(nil) == 3)
+	isTrue(t, arr.Median(Field("Age")) == 25)
+	isTrue(t, arr.AsParallel(2).Map(Field("Age")).Sum(nil) == 85)
+	isTrue(t, arr.AsEnumerable().Map(Field("Age")).Count(nil) == 3)
+
+	us := LambdaArray([]user{{"Abraham", 20}, {"Edith", 25}})
+	// unexported fields
+	isTrue(t, fmt.Sprint(us.Map(Field("name")).Pointer()) == "[Abraham Edith]")
+	isTrue(t, us.Max(Field("age")).(user).name == "Edith")
+}
+
+func TestField_Predicate(t *testing.T) {
+	defer report(t, time.Now())
+	ps := []profile{
+		{member{Name: "Abraham", Address: &address{"Paris"}}, true, map[string]interface{}{"rank": 1.0}},
+		{member{Name: "Edith"}, false, nil},
+		{member{Name: "Charles"}, true, map[string]interface{}{"rank": 3.0}},
+	}
+	arr := LambdaArray(ps)
+	isTrue(t, arr.Count(Field("active")) == 2)
+	isTrue(t, arr.Any(Field("active")))
+	isFalse(t, arr.All(Field("active")))
+	isTrue(t, fmt.Sprint(arr.Filter(Field("active")).Map(Field("Name")).Pointer()) == "[Abraham Charles]")
+	last, _ := arr.Last(Field("active"))
+	isTrue(t, last.(profile).Name == "Charles")
+	isTrue(t, arr.Contains(Field("active")))
+	// promoted fields of the embedded struct, and fields behind interface{}
+	isTrue(t, fmt.Sprint(arr.Map(Field("member.Address.City")).Pointer()) == "[Paris  ]")
+	// CompileExpress reads unexported fields the same way
+	isTrue(t, fmt.Sprint(arr.Map(Lambda("p => p.member")).Map(Field("Name")).Pointer()) == "[Abraham Edith Charles]")
+	isTrue(t, arr.Count(Lambda("p => p.active && p.member.Address.City == 'Paris'")) == 1)
+	isTrue(t, fmt.Sprint(arr.Map(Field("meta.rank")).Pointer()) == "[1 <nil> 3]")
+}
+
+func TestField_SortMT(t *testing.T) {
+	defer report(t, time.Now())
+	type score struct {
+		Name  string
+		Score int
+	}
+	arr := LambdaArray([]score{{"a", 3}, {"c", 1}, {"b", 2}, {"d", 2}})
+	isTrue(t, fmt.Sprint(arr.Sort(Field("Score")).Map(Field("Score")).Pointer()) == "[1 2 2 3]")
+	isTrue(t, fmt.Sprint(arr.SortMT(Field("Score")).Map(Field("Score")).Pointer()) == "[1 2 2 3]")
+	isTrue(t, fmt.Sprint(arr.Sort(Field("Name")).Pointer()) == fmt.Sprint(arr.SortMT(Field("Name")).Pointer()))
+	isTrue(t, fmt.Sprint(arr.Map(Field("Score")).SortMT(nil).Pointer()) == "[1 2 2 3]")
+	sorted, err := arr.WithContext(context.Background()).SortMT(Field("Name"))
+	isTrue(t, err == nil && fmt.Sprint(sorted.Map(Field("Name")).Pointer()) == "[a b c d]")
+}
+
+func TestField_Error(t *testing.T) {
+	defer report(t, time.Now())
+	_, err := FieldOf(member{}, "Address.Zip")
+	var ee *ExpressError
+	isTrue(t, errors.As(err, &ee))
+	_, err = FieldOf(member{}, "Name.First")
+	isTrue(t, errors.As(err, &ee))
+	s, err := FieldOf(&member{}, "Address.City")
+	isTrue(t, err == nil && s.String() == "Address.City")
+
+	// the field is checked before any element is selected
+	_, err = TryLambdaArray(makeMembers()).Sum(Field("Adress"))
+	isTrue(t, errors.As(err, &ee))
+	var oe *OpError
+	isTrue(t, errors.As(err, &oe) && oe.Arg == "express")
+	_, err = TryLambdaArray(makeMembers()).Filter(Field("Name")).Array()
+	isTrue(t, errors.As(err, &ee))
+	_, err = TryLambdaArray(makeMembers()).Sort(Field("Tags")).Array()
+	isTrue(t, errors.As(err, &ee))
+
+	defer func() {
+		isTrue(t, recover() != nil)
+	}()
+	Field("Address..City")
+}
+
+func TestField_Cache(t *testing.T) {
+	defer report(t, time.Now())
+	f := Field("Address.City")
+	a := f.resolve(reflect.TypeOf(member{}))
+	b := Field("Address.City").resolve(reflect.TypeOf(member{}))
+	isTrue(t, a == b)
+	isTrue(t, a.out == reflect.TypeOf(""))
+}
@@ -0,0 +1,108 @@
+package lambda
+
+import (
+	"reflect"
+)
+
+// the element of Array.GroupBy
+// the members are an Array, so Sum, Average, Count, Max, Min... can be called on the group directly
+// eg: g.Sum(func(u user) int { return u.age })
+type Group struct {
+	Key interface{}
+	Array
+}
+
+// Lookup is a one-to-many index from key to the elements with the key
+type Lookup interface {
+
+	// elements of the key, empty Array when the key does not exist
+	Get(key interface{}) Array
+
+	// Determines whether the lookup contains the key
+	Contains(key interface{}) bool
+
+	// keys in first-seen order, Keys().Pointer().([]K)
+	Keys() Array
+
+	// number of keys
+	Len() int
+}
+
+type _lookup struct {
+	// element type
+	elementType reflect.Type
+	// key type
+	keyType reflect.Type
+	// keys in first-seen order
+	keys reflect.Value
+	// elements of each key, in the order of keys
+	groups []reflect.Value
+	// positions of the elements by key, matched like Distinct and the joins
+	index *_index
+}
+
+// group elements by key
+// express func(ele T) K, keys are matched like Distinct and the joins do, see newIndex
+func (p *_array) grouping(express interface{}) *_lookup {
+	express = bindExpress(express, p.elementType)
+	kt := checkExpressRARTO(express, []reflect.Type{p.elementType})
+	x := p.indexBy(callKey(reflect.ValueOf(express)), kt)
+	l := &_lookup{
+		elementType: p.elementType,
+		keyType:     kt,
+		keys:        reflect.MakeSlice(reflect.SliceOf(kt), len(x.keys), len(x.keys)),
+		groups:      make([]reflect.Value, len(x.keys)),
+		index:       x,
+	}
+	for i, k := range x.keys {
+		if k != nil {
+			l.keys.Index(i).Set(reflect.ValueOf(k))
+		}
+		g := reflect.MakeSlice(reflect.SliceOf(p.elementType), len(x.groups[i]), len(x.groups[i]))
+		for j, pos := range x.groups[i] {
+			g.Index(j).Set(p.value.Index(pos))
+		}
+		l.groups[i] = g
+	}
+	return l
+}
+
+// the position of key in keys, -1 when not found or key is not of the key type
+func (l *_lookup) find(key interface{}) int {
+	if t := reflect.TypeOf(key); t == nil || !t.AssignableTo(l.keyType) {
+		return -1
+	}
+	return l.index.find(key)
+}
+
+func (p *_array) GroupBy(express interface{}) Array {
+	l := p.grouping(express)
+	ret := make([]Group, len(l.groups))
+	for i, g := range l.groups {
+		ret[i] = Group{l.keys.Index(i).Interface(), innerLambdaArray(g)}
+	}
+	return LambdaArray(ret)
+}
+
+func (p *_array) ToLookup(express interface{}) Lookup {
+	return p.grouping(express)
+}
+
+func (l *_lookup) Get(key interface{}) Array {
+	if i := l.find(key); i >= 0 {
+		return innerLambdaArray(l.groups[i])
+	}
+	return innerLambdaArray(reflect.MakeSlice(reflect.SliceOf(l.elementType), 0, 0))
+}
+
+func (l *_lookup) Contains(key interface{}) bool {
+	return l.find(key) >= 0
+}
+
+func (l *_lookup) Keys() Array {
+	return innerLambdaArray(l.keys)
+}
+
+func (l *_lookup) Len() int {
+	return l.keys.Len()
+}
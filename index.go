@@ -0,0 +1,162 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	compareType = reflect.TypeOf((*Compare)(nil)).Elem()
+	equalType   = reflect.TypeOf((*Equal)(nil)).Elem()
+)
+
+// _index groups positions by key.
+// keys implement Compare or Equal are matched like Contains does, by scanning the distinct keys,
+// structs with lambda tags are hashed by their key fields,
+// the other keys are hashed, so it is not quadratic for number, string and comparable struct keys.
+// a dynamic value in an interface which is not hashable, eg: an object or array of decoded JSON, is matched by reflect.DeepEqual
+type _index struct {
+	// distinct keys in first-seen order
+	keys []interface{}
+	// positions of each distinct key
+	groups [][]int
+	// key to the position in keys, nil when keys are matched by equals
+	hashed map[interface{}]int
+	// hash key of key, nil when key is the hash key
+	hash func(key interface{}) interface{}
+	// key equality of Compare or Equal keys
+	equals func(a, b interface{}) bool
+	// the key type holds interfaces, so a key may not be hashable
+	dynamic bool
+	// positions in keys of the keys which are not hashable
+	unhashable []int
+}
+
+func newIndex(keyType reflect.Type) *_index {
+	x := &_index{}
+	tags := tagsOf(keyType)
+	switch {
+	case keyType.Implements(compareType):
+		x.equals = func(a, b interface{}) bool { return a.(Compare).CompareTo(b) == 0 }
+	case keyType.Implements(equalType):
+		x.equals = func(a, b interface{}) bool { return a.(Equal).Equals(b) }
+	case tags != nil && tags.hashable:
+		x.hashed = map[interface{}]int{}
+		x.hash = func(key interface{}) interface{} { return tags.hash(reflect.ValueOf(key)) }
+	case tags != nil:
+		x.equals = func(a, b interface{}) bool { return tags.equal(reflect.ValueOf(a), reflect.ValueOf(b)) }
+	case keyType.Comparable():
+		x.hashed = map[interface{}]int{}
+		x.dynamic = holdsInterface(keyType, nil)
+	default:
+		panic(fmt.Sprintf("key type %s is not comparable and does not implement Compare or Equal", keyType.String()))
+	}
+	return x
+}
+
+func (x *_index) hashKey(key interface{}) interface{} {
+	if x.hash != nil {
+		return x.hash(key)
+	}
+	return key
+}
+
+// t is or has an element or field of interface type
+func holdsInterface(t reflect.Type, seen map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Array:
+		return holdsInterface(t.Elem(), seen)
+	case reflect.Struct:
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = map[reflect.Type]bool{}
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			if holdsInterface(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// v can be a map key, the dynamic values in interfaces included
+func hashable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || hashable(v.Elem())
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return false
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashable(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashable(v.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// key is hashed, false for Compare and Equal keys and the keys holding a value which is not hashable
+func (x *_index) hashes(key interface{}) bool {
+	return x.hashed != nil && (!x.dynamic || hashable(reflect.ValueOf(key)))
+}
+
+// the position of key in distinct keys, -1 when not found
+func (x *_index) find(key interface{}) int {
+	if x.hashed != nil && !x.hashes(key) {
+		for _, i := range x.unhashable {
+			if reflect.DeepEqual(key, x.keys[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+	if x.hashed != nil {
+		if i, ok := x.hashed[x.hashKey(key)]; ok {
+			return i
+		}
+		return -1
+	}
+	for i, k := range x.keys {
+		if x.equals(key, k) {
+			return i
+		}
+	}
+	return -1
+}
+
+// add position of key, returns false when key was already in the index
+func (x *_index) add(key interface{}, pos int) bool {
+	if i := x.find(key); i >= 0 {
+		x.groups[i] = append(x.groups[i], pos)
+		return false
+	}
+	if x.hashes(key) {
+		x.hashed[x.hashKey(key)] = len(x.keys)
+	} else if x.hashed != nil {
+		x.unhashable = append(x.unhashable, len(x.keys))
+	}
+	x.keys = append(x.keys, key)
+	x.groups = append(x.groups, []int{pos})
+	return true
+}
+
+// positions of key, nil when not found
+func (x *_index) get(key interface{}) []int {
+	if i := x.find(key); i >= 0 {
+		return x.groups[i]
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var arrayType = reflect.TypeOf((*Array)(nil)).Elem()
+
+func asInner(arr Array) *_array {
+	if p, ok := arr.(*_array); ok {
+		return p
+	}
+	return LambdaArray(arr.Pointer()).(*_array)
+}
+
+// check the key expresses of join, returns the key functions
+// the keys are the 2nd and 3rd arguments of the join operators
+func checkJoinKeys(outer, inner *_array, outerKey, innerKey interface{}) (reflect.Value, reflect.Value) {
+	outerKey, innerKey = bindExpress(outerKey, outer.elementType), bindExpress(innerKey, inner.elementType)
+	var okt, ikt reflect.Type
+	argument(2, func() { okt = checkExpressRARTO(outerKey, []reflect.Type{outer.elementType}) })
+	argument(3, func() { ikt = checkExpressRARTO(innerKey, []reflect.Type{inner.elementType}) })
+	if okt != ikt {
+		panic(fmt.Errorf("outer key type %s is not inner key type %s", okt.String(), ikt.String()))
+	}
+	return reflect.ValueOf(outerKey), reflect.ValueOf(innerKey)
+}
+
+// index positions of elements by key
+func (p *_array) indexBy(keyFn func(v reflect.Value) reflect.Value, keyType reflect.Type) *_index {
+	x := newIndex(keyType)
+	p.EachV(func(v reflect.Value, i int) {
+		x.add(keyFn(v).Interface(), i)
+	})
+	return x
+}
+
+func callKey(fn reflect.Value) func(v reflect.Value) reflect.Value {
+	return func(v reflect.Value) reflect.Value {
+		return fn.Call([]reflect.Value{v})[0]
+	}
+}
+
+// correlate with inner and call fn with the inner matches of every outer element
+func (p *_array) correlate(inner *_array, outerKey, innerKey interface{}, fn func(o reflect.Value, matches []int)) {
+	okf, ikf := checkJoinKeys(p, inner, outerKey, innerKey)
+	x := inner.indexBy(callKey(ikf), ikf.Type().Out(0))
+	p.EachV(func(o reflect.Value, _ int) {
+		fn(o, x.get(okf.Call([]reflect.Value{o})[0].Interface()))
+	})
+}
+
+func (p *_array) InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) Array {
+	inner := asInner(other)
+	var ot reflect.Type
+	argument(4, func() { ot = checkExpressRARTO(resultSelector, []reflect.Type{p.elementType, inner.elementType}) })
+	fn := reflect.ValueOf(resultSelector)
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), 0, 0)
+	p.correlate(inner, outerKey, innerKey, func(o reflect.Value, matches []int) {
+		for _, i := range matches {
+			ret = reflect.Append(ret, fn.Call([]reflect.Value{o, inner.value.Index(i)})[0])
+		}
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) Array {
+	inner := asInner(other)
+	var ot reflect.Type
+	argument(4, func() { ot = checkExpressRARTO(resultSelector, []reflect.Type{p.elementType, inner.elementType}) })
+	fn := reflect.ValueOf(resultSelector)
+	def := reflect.Zero(inner.elementType)
+	if defaultInner != nil {
+		if t := reflect.TypeOf(defaultInner); !t.AssignableTo(inner.elementType) {
+			panic(fmt.Sprintf("default type[%s] is not %s.", t.String(), inner.elementType.String()))
+		}
+		def = reflect.ValueOf(defaultInner)
+	}
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), 0, 0)
+	p.correlate(inner, outerKey, innerKey, func(o reflect.Value, matches []int) {
+		if len(matches) == 0 {
+			ret = reflect.Append(ret, fn.Call([]reflect.Value{o, def})[0])
+		}
+		for _, i := range matches {
+			ret = reflect.Append(ret, fn.Call([]reflect.Value{o, inner.value.Index(i)})[0])
+		}
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) Array {
+	inner := asInner(other)
+	var ot reflect.Type
+	argument(4, func() { ot = checkExpressRARTO(resultSelector, []reflect.Type{p.elementType, arrayType}) })
+	fn := reflect.ValueOf(resultSelector)
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), 0, 0)
+	p.correlate(inner, outerKey, innerKey, func(o reflect.Value, matches []int) {
+		group := reflect.MakeSlice(reflect.SliceOf(inner.elementType), 0, len(matches))
+		for _, i := range matches {
+			group = reflect.Append(group, inner.value.Index(i))
+		}
+		ret = reflect.Append(ret, fn.Call([]reflect.Value{o, reflect.ValueOf(innerLambdaArray(group))})[0])
+	})
+	return innerLambdaArray(ret)
+}
@@ -0,0 +1,420 @@
+package lambda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONSelector selects a value of decoded JSON documents by a JSON path, see JSONPath
+type JSONSelector struct {
+	// JSON path, eg: $.user.age
+	path string
+	// map keys (string) and array indexes (int)
+	steps []interface{}
+	// coerced type of the value, nil keeps the value as interface{}
+	as reflect.Type
+}
+
+// JSONPath makes the selector of the value at path in decoded JSON documents,
+// eg: JSONPath("$.user.age"), JSONPath("$.tags[0]"), JSONPath(`$["first name"]`).
+// the elements are interface{}, map[string]V or []V as decoded by encoding/json,
+// a missing key, an index out of range or a value of another shape selects nil, the null of JSON.
+// it is accepted anywhere an express is accepted like Field,
+// AsFloat, AsInt, AsString and AsBool coerce the value for Sum, Average, etc.
+// and Eq, Gt, Exists, etc. make the predicates of Filter, Any, Count, etc.
+func JSONPath(path string) *JSONSelector {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return &JSONSelector{path: path, steps: steps}
+}
+
+func parseJSONPath(path string) ([]interface{}, error) {
+	fail := func(pos int, msg string) error {
+		return &ExpressSyntaxError{Pos: pos, Msg: fmt.Sprintf("json path %s: %s", path, msg)}
+	}
+	if !strings.HasPrefix(path, "$") {
+		return nil, fail(0, "must start with $")
+	}
+	var steps []interface{}
+	for i := 1; i < len(path); {
+		switch path[i] {
+		case '.':
+			j := i + 1
+			for j < len(path) && path[j] != '.' && path[j] != '[' {
+				j++
+			}
+			if j == i+1 {
+				return nil, fail(i, "empty name")
+			}
+			steps = append(steps, path[i+1:j])
+			i = j
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fail(i, "missing ]")
+			}
+			inner := path[i+1 : i+end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				steps = append(steps, inner[1:len(inner)-1])
+			} else if n, err := strconv.Atoi(inner); err == nil {
+				steps = append(steps, n)
+			} else {
+				return nil, fail(i+1, fmt.Sprintf("%q is not an index or a quoted name", inner))
+			}
+			i += end + 1
+		default:
+			return nil, fail(i, fmt.Sprintf("unexpected %q", path[i]))
+		}
+	}
+	return steps, nil
+}
+
+func (s *JSONSelector) String() string {
+	return s.path
+}
+
+func (s *JSONSelector) coerce(t reflect.Type) *JSONSelector {
+	return &JSONSelector{s.path, s.steps, t}
+}
+
+// the value as float64, see Coerce
+func (s *JSONSelector) AsFloat() *JSONSelector {
+	return s.coerce(reflect.TypeOf(float64(0)))
+}
+
+// the value as int64, see Coerce
+func (s *JSONSelector) AsInt() *JSONSelector {
+	return s.coerce(reflect.TypeOf(int64(0)))
+}
+
+// the value as string, see Coerce
+func (s *JSONSelector) AsString() *JSONSelector {
+	return s.coerce(reflect.TypeOf(""))
+}
+
+// the value as bool, see Coerce
+func (s *JSONSelector) AsBool() *JSONSelector {
+	return s.coerce(reflect.TypeOf(true))
+}
+
+// the value at the path of doc, found is false when a key or an index is missing
+func (s *JSONSelector) lookup(doc interface{}) (v interface{}, found bool) {
+	cur := reflect.ValueOf(doc)
+	for _, step := range s.steps {
+		cur = deref(cur)
+		for cur.IsValid() && cur.Kind() == reflect.Interface {
+			cur = deref(cur.Elem())
+		}
+		if !cur.IsValid() {
+			return nil, false
+		}
+		switch key := step.(type) {
+		case string:
+			if cur.Kind() != reflect.Map || cur.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			cur = cur.MapIndex(reflect.ValueOf(key).Convert(cur.Type().Key()))
+		case int:
+			if cur.Kind() != reflect.Slice && cur.Kind() != reflect.Array {
+				return nil, false
+			}
+			if key < 0 {
+				key += cur.Len()
+			}
+			if key < 0 || key >= cur.Len() {
+				return nil, false
+			}
+			cur = cur.Index(key)
+		}
+		if !cur.IsValid() {
+			return nil, false
+		}
+	}
+	if !cur.IsValid() || !cur.CanInterface() {
+		return nil, cur.IsValid()
+	}
+	return cur.Interface(), true
+}
+
+// the selected value of doc, coerced when the selector has a type
+func (s *JSONSelector) get(doc interface{}) interface{} {
+	v, _ := s.lookup(doc)
+	if s.as == nil {
+		return v
+	}
+	return Coerce(v, s.as)
+}
+
+func (s *JSONSelector) out() reflect.Type {
+	if s.as == nil {
+		return anyType
+	}
+	return s.as
+}
+
+// func(ele T) V selecting the value of elements of t
+func (s *JSONSelector) bind(t reflect.Type) interface{} {
+	out := s.out()
+	ft := reflect.FuncOf([]reflect.Type{t}, []reflect.Type{out}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		v := s.get(args[0].Interface())
+		if v == nil {
+			return []reflect.Value{reflect.Zero(out)}
+		}
+		return []reflect.Value{reflect.ValueOf(v)}
+	}).Interface()
+}
+
+// func(a, b T) bool of the elements of t, true when the value of a is less than the value of b by CompareJSON
+func (s *JSONSelector) less(t reflect.Type) interface{} {
+	ft := reflect.FuncOf([]reflect.Type{t, t}, []reflect.Type{reflect.TypeOf(true)}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		c := CompareJSON(s.get(args[0].Interface()), s.get(args[1].Interface()))
+		return []reflect.Value{reflect.ValueOf(c < 0)}
+	}).Interface()
+}
+
+// JSONPredicate is a condition on the value selected by a JSONSelector,
+// it is accepted by Filter, Any, All, Count, First, etc.
+type JSONPredicate struct {
+	s    *JSONSelector
+	test func(v interface{}, found bool) bool
+}
+
+func (p *JSONPredicate) bind(t reflect.Type) interface{} {
+	ft := reflect.FuncOf([]reflect.Type{t}, []reflect.Type{reflect.TypeOf(true)}, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		v, found := p.s.lookup(args[0].Interface())
+		// null stays null, so the ordering comparisons with it are false
+		if p.s.as != nil && v != nil {
+			v = Coerce(v, p.s.as)
+		}
+		return []reflect.Value{reflect.ValueOf(p.test(v, found))}
+	}).Interface()
+}
+
+// to coerced like the selected values, eg: "25" is 25 for AsFloat
+func (s *JSONSelector) operand(to interface{}) interface{} {
+	if s.as == nil || to == nil {
+		return to
+	}
+	return Coerce(to, s.as)
+}
+
+func (s *JSONSelector) compared(to interface{}, test func(c int) bool) *JSONPredicate {
+	to = s.operand(to)
+	return &JSONPredicate{s, func(v interface{}, _ bool) bool {
+		// the ordering comparisons with null are false
+		return v != nil && to != nil && test(CompareJSON(v, to))
+	}}
+}
+
+// the value equals v by CompareJSON, a missing value equals nil
+func (s *JSONSelector) Eq(v interface{}) *JSONPredicate {
+	v = s.operand(v)
+	return &JSONPredicate{s, func(x interface{}, _ bool) bool {
+		return CompareJSON(x, v) == 0
+	}}
+}
+
+// the value does not equal v by CompareJSON
+func (s *JSONSelector) Ne(v interface{}) *JSONPredicate {
+	p := s.Eq(v)
+	return &JSONPredicate{s, func(v interface{}, found bool) bool { return !p.test(v, found) }}
+}
+
+// the value is greater than v by CompareJSON, false when either is null
+func (s *JSONSelector) Gt(v interface{}) *JSONPredicate {
+	return s.compared(v, func(c int) bool { return c > 0 })
+}
+
+// the value is greater than or equal to v, false when either is null
+func (s *JSONSelector) Ge(v interface{}) *JSONPredicate {
+	return s.compared(v, func(c int) bool { return c >= 0 })
+}
+
+// the value is less than v, false when either is null
+func (s *JSONSelector) Lt(v interface{}) *JSONPredicate {
+	return s.compared(v, func(c int) bool { return c < 0 })
+}
+
+// the value is less than or equal to v, false when either is null
+func (s *JSONSelector) Le(v interface{}) *JSONPredicate {
+	return s.compared(v, func(c int) bool { return c <= 0 })
+}
+
+// the path exists, its value may be null
+func (s *JSONSelector) Exists() *JSONPredicate {
+	return &JSONPredicate{s, func(_ interface{}, found bool) bool { return found }}
+}
+
+// the value is null or the path is missing
+func (s *JSONSelector) IsNull() *JSONPredicate {
+	return &JSONPredicate{s, func(v interface{}, _ bool) bool { return v == nil }}
+}
+
+// rank of the JSON types in CompareJSON
+const (
+	jsonNull = iota
+	jsonBool
+	jsonNumber
+	jsonString
+	jsonArray
+	jsonObject
+)
+
+func jsonRank(v reflect.Value) int {
+	switch {
+	case !v.IsValid():
+		return jsonNull
+	case v.Type() == reflect.TypeOf(json.Number("")) || isNumber(v.Type()):
+		return jsonNumber
+	case isBool(v.Type()):
+		return jsonBool
+	case isString(v.Type()):
+		return jsonString
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		return jsonArray
+	}
+	return jsonObject
+}
+
+// CompareJSON is the three-way comparison of two JSON values,
+// numbers of any type and json.Number are compared as float64, strings are compared as text,
+// so "10" < "9" and "10" > 9, select the values by AsFloat or AsInt to compare strings of numbers as numbers.
+// values of different kinds are ordered null < bool < number < string < array < object,
+// arrays and objects are equal when they are deeply equal
+func CompareJSON(a, b interface{}) int {
+	av, bv := deref(reflect.ValueOf(a)), deref(reflect.ValueOf(b))
+	ar, br := jsonRank(av), jsonRank(bv)
+	if ar != br {
+		return compareOrdered(int64(ar), int64(br))
+	}
+	switch ar {
+	case jsonNull:
+		return 0
+	case jsonBool:
+		return compareBool(av.Bool(), bv.Bool())
+	case jsonNumber:
+		af, _ := toFloat(av)
+		bf, _ := toFloat(bv)
+		return compareFloat(af, bf)
+	case jsonString:
+		return strings.Compare(av.String(), bv.String())
+	}
+	if reflect.DeepEqual(av.Interface(), bv.Interface()) {
+		return 0
+	}
+	// not ordered, compared by their JSON text to be deterministic
+	at, _ := json.Marshal(av.Interface())
+	bt, _ := json.Marshal(bv.Interface())
+	return strings.Compare(string(at), string(bt))
+}
+
+func toFloat(v reflect.Value) (float64, error) {
+	switch {
+	case v.Type() == reflect.TypeOf(json.Number("")):
+		return strconv.ParseFloat(v.String(), 64)
+	case isInt(v.Type()):
+		return float64(v.Int()), nil
+	case isUint(v.Type()):
+		return float64(v.Uint()), nil
+	case isFloat(v.Type()):
+		return v.Float(), nil
+	case isString(v.Type()):
+		return strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+	case isBool(v.Type()):
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("%s is not a number", v.Type().String())
+}
+
+// the integer of v, json.Number and strings are parsed exactly,
+// false when v is not a number or is out of the range of int64
+func toInt(v reflect.Value) (int64, bool) {
+	switch {
+	case isInt(v.Type()):
+		return v.Int(), true
+	case isUint(v.Type()):
+		return int64(v.Uint()), v.Uint() <= math.MaxInt64
+	case v.Type() == reflect.TypeOf(json.Number("")) || isString(v.Type()):
+		i, err := strconv.ParseInt(strings.TrimSpace(v.String()), 10, 64)
+		if err == nil {
+			return i, true
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			return 0, false
+		}
+	}
+	// fractions are truncated
+	f, err := toFloat(v)
+	if err != nil || math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// Coerce converts a JSON value to float64, int64, string or bool of t.
+// null and the values that can not be converted are the zero value,
+// numbers are parsed from strings and are 1 or 0 from bool,
+// integers are parsed exactly from json.Number and strings, values out of the range of t can not be converted,
+// strings are formatted from numbers and bool, arrays and objects are their JSON text,
+// bool is parsed from strings and is true for numbers other than 0
+func Coerce(v interface{}, t reflect.Type) interface{} {
+	rv := deref(reflect.ValueOf(v))
+	ret := reflect.New(t).Elem()
+	if !rv.IsValid() {
+		return ret.Interface()
+	}
+	switch {
+	case isFloat(t):
+		if f, err := toFloat(rv); err == nil {
+			ret.SetFloat(f)
+		}
+	case isInt(t):
+		if i, ok := toInt(rv); ok && !ret.OverflowInt(i) {
+			ret.SetInt(i)
+		}
+	case isString(t):
+		switch jsonRank(rv) {
+		case jsonString:
+			ret.SetString(rv.String())
+		case jsonNumber:
+			if rv.Type() == reflect.TypeOf(json.Number("")) {
+				ret.SetString(rv.String())
+				break
+			}
+			f, _ := toFloat(rv)
+			ret.SetString(strconv.FormatFloat(f, 'f', -1, 64))
+		case jsonBool:
+			ret.SetString(strconv.FormatBool(rv.Bool()))
+		default:
+			text, _ := json.Marshal(rv.Interface())
+			ret.SetString(string(text))
+		}
+	case isBool(t):
+		switch jsonRank(rv) {
+		case jsonBool:
+			ret.SetBool(rv.Bool())
+		case jsonString:
+			b, _ := strconv.ParseBool(strings.TrimSpace(rv.String()))
+			ret.SetBool(b)
+		case jsonNumber:
+			f, _ := toFloat(rv)
+			ret.SetBool(f != 0)
+		}
+	default:
+		panic("can not coerce to " + t.String())
+	}
+	return ret.Interface()
+}
@@ -0,0 +1,152 @@
+package lambda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const documents = `[
+	{"id": 1, "user": {"name": "Abraham", "age": 20}, "tags": ["a", "b"], "active": true},
+	{"id": 2, "user": {"name": "Edith", "age": "25"}, "tags": [], "active": "true"},
+	{"id": 3, "user": {"name": "Charles", "age": 40}, "active": false},
+	{"id": 4, "user": {"name": "Anthony", "age": null}},
+	{"id": 5, "first name": "Abel"}
+]`
+
+func makeDocuments() []interface{} {
+	var docs []interface{}
+	if err := json.Unmarshal([]byte(documents), &docs); err != nil {
+		panic(err)
+	}
+	return docs
+}
+
+func ids(arr Array) string {
+	return fmt.Sprint(arr.Map(JSONPath("$.id").AsInt()).Pointer())
+}
+
+func TestJSONPath(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeDocuments())
+	isTrue(t, fmt.Sprint(arr.Map(JSONPath("$.user.name")).Pointer()) == "[Abraham Edith Charles Anthony <nil>]")
+	isTrue(t, fmt.Sprint(arr.Map(JSONPath("$.tags[0]")).Pointer()) == "[a <nil> <nil> <nil> <nil>]")
+	isTrue(t, fmt.Sprint(arr.Map(JSONPath("$.tags[-1]")).Pointer()) == "[b <nil> <nil> <nil> <nil>]")
+	isTrue(t, fmt.Sprint(arr.Map(JSONPath(`$["first name"]`)).Pointer()) == "[<nil> <nil> <nil> <nil> Abel]")
+	isTrue(t, fmt.Sprint(arr.Map(JSONPath("$.user.age").AsFloat()).Pointer()) == "[20 25 40 0 0]")
+	isTrue(t, arr.Sum(JSONPath("$.user.age").AsFloat()) == float64(85))
+	isTrue(t, arr.Filter(JSONPath("$.user.age").Exists()).Average(JSONPath("$.user.age").AsInt()) == 85.0/4)
+
+	// null is the least
+	isTrue(t, arr.Max(JSONPath("$.user.name")).(map[string]interface{})["id"] == float64(2))
+	isTrue(t, arr.Min(JSONPath("$.user.name")).(map[string]interface{})["id"] == float64(5))
+	isTrue(t, ids(arr.OrderBy(JSONPath("$.user.age").AsFloat())) == "[4 5 1 2 3]")
+	// "25" is compared as a number
+	isTrue(t, ids(LambdaArray(makeDocuments()).Sort(JSONPath("$.user.age"))) == "[4 5 1 3 2]")
+	isTrue(t, ids(LambdaArray(makeDocuments()).Sort(JSONPath("$.user.age").AsFloat())) == "[4 5 1 2 3]")
+	isTrue(t, arr.GroupBy(JSONPath("$.active").AsBool()).Count(nil) == 2)
+
+	docs := LambdaArray([]map[string]interface{}{{"n": 1.5}, {"n": 2.5}, {}})
+	isTrue(t, docs.Sum(JSONPath("$.n").AsFloat()) == float64(4))
+}
+
+func TestJSONPredicate(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeDocuments())
+	// the string "25" of document 2 is a number by AsFloat only, so are the operands
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").Gt(21))) == "[2 3]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").Lt(30))) == "[1]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").AsFloat().Lt(30))) == "[1 2]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").AsFloat().Le("25"))) == "[1 2]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").AsInt().Eq("40"))) == "[3]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").Eq(nil))) == "[4 5]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").Ne(nil))) == "[1 2 3]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").IsNull())) == "[4 5]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.user.age").Exists())) == "[1 2 3 4]")
+	isTrue(t, ids(arr.Filter(JSONPath("$.active").AsBool().Eq(true))) == "[1 2]")
+	isTrue(t, arr.Count(JSONPath("$.user.name").Lt("B")) == 2)
+	isTrue(t, arr.Any(JSONPath("$.tags[1]").Eq("b")))
+	isFalse(t, arr.All(JSONPath("$.id").Ge(2)))
+	first, _ := arr.First(JSONPath("$.user.name").Ge("C"))
+	isTrue(t, first.(map[string]interface{})["id"] == float64(2))
+}
+
+func TestCompareJSON(t *testing.T) {
+	defer report(t, time.Now())
+	isTrue(t, CompareJSON(nil, false) < 0)
+	isTrue(t, CompareJSON(true, 0) < 0)
+	isTrue(t, CompareJSON(json.Number("10"), 9) > 0)
+	// strings are text, they are not numbers implicitly, so the order is transitive
+	isTrue(t, CompareJSON("10", 9.5) > 0)
+	isTrue(t, CompareJSON("abc", 9.5) > 0)
+	isTrue(t, CompareJSON(10, "10") < 0)
+	isTrue(t, CompareJSON("10", "9") < 0)
+	isTrue(t, CompareJSON("02134", "9") < 0)
+	isTrue(t, CompareJSON("1e3", "999") < 0)
+	zips := LambdaArray([]interface{}{"10001", "02134", "9", "abc"})
+	byText := zips.Sort(func(a, b interface{}) bool { return CompareJSON(a, b) < 0 }).Pointer()
+	isTrue(t, fmt.Sprint(byText) == "[02134 10001 9 abc]")
+	// numbers by AsFloat explicitly, abc is not a number so it is 0
+	byNumber := zips.Sort(JSONPath("$").AsFloat()).Pointer()
+	isTrue(t, fmt.Sprint(byNumber) == "[abc 9 02134 10001]")
+	mixed := []interface{}{"9", 10.0, "abc", "10", 8.0, nil, "1e1", true}
+	for _, a := range mixed {
+		for _, b := range mixed {
+			for _, c := range mixed {
+				if CompareJSON(a, b) <= 0 && CompareJSON(b, c) <= 0 {
+					isTrue(t, CompareJSON(a, c) <= 0)
+				}
+			}
+		}
+	}
+	isTrue(t, CompareJSON([]interface{}{1.0}, []interface{}{1.0}) == 0)
+	isTrue(t, CompareJSON(map[string]interface{}{}, "x") > 0)
+
+	isTrue(t, Coerce("12.5", reflect.TypeOf(float64(0))) == 12.5)
+	isTrue(t, Coerce("x", reflect.TypeOf(float64(0))) == float64(0))
+	isTrue(t, Coerce(12.9, reflect.TypeOf(int64(0))) == int64(12))
+	isTrue(t, Coerce(json.Number("9007199254740993"), reflect.TypeOf(int64(0))) == int64(9007199254740993))
+	isTrue(t, Coerce(" -9007199254740993", reflect.TypeOf(int64(0))) == int64(-9007199254740993))
+	isTrue(t, Coerce("1.5e3", reflect.TypeOf(int64(0))) == int64(1500))
+	isTrue(t, Coerce(1e30, reflect.TypeOf(int64(0))) == int64(0))
+	isTrue(t, Coerce("99999999999999999999", reflect.TypeOf(int64(0))) == int64(0))
+	isTrue(t, Coerce(300, reflect.TypeOf(int8(0))) == int8(0))
+	isTrue(t, Coerce(json.Number("9007199254740993"), reflect.TypeOf("")) == "9007199254740993")
+	isTrue(t, Coerce(12.5, reflect.TypeOf("")) == "12.5")
+	isTrue(t, Coerce([]interface{}{1.0, "a"}, reflect.TypeOf("")) == `[1,"a"]`)
+	isTrue(t, Coerce("true", reflect.TypeOf(true)) == true)
+	isTrue(t, Coerce(nil, reflect.TypeOf(true)) == false)
+
+	var se *ExpressSyntaxError
+	for _, path := range []string{"user.age", "$.", "$.tags[x]", "$.tags[0"} {
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				isTrue(t, errors.As(err, &se))
+			}()
+			JSONPath(path)
+		}()
+	}
+}
+
+func TestJSONKeys(t *testing.T) {
+	defer report(t, time.Now())
+	// objects and arrays of decoded JSON are not hashable, they are matched by reflect.DeepEqual
+	docs := append(makeDocuments(), makeDocuments()[0])
+	arr := LambdaArray(docs)
+	isTrue(t, arr.Distinct().Count(nil) == 5)
+	isTrue(t, arr.Union(LambdaArray(makeDocuments())).Count(nil) == 5)
+	isTrue(t, arr.Intersect(LambdaArray(makeDocuments()[:2])).Count(nil) == 2)
+	isTrue(t, ids(arr.Except(LambdaArray(makeDocuments()[1:]))) == "[1]")
+	isTrue(t, arr.Mode(nil).(map[string]interface{})["id"] == float64(1))
+	isTrue(t, arr.GroupBy(JSONPath("$.tags")).Count(nil) == 3)
+	isTrue(t, arr.ToLookup(JSONPath("$.tags")).Get([]interface{}{"a", "b"}).Count(nil) == 2)
+	pairs := arr.InnerJoin(LambdaArray(makeDocuments()), JSONPath("$.user"), JSONPath("$.user"),
+		func(a, b interface{}) interface{} { return b })
+	isTrue(t, pairs.Count(nil) == 6)
+	// numbers and strings are still hashed
+	isTrue(t, arr.DistinctBy(JSONPath("$.id")).Count(nil) == 5)
+}
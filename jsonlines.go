@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+)
+
+// StreamJSONLines makes Enumerable of the lines of r decoded into sample's type by encoding/json,
+// so struct fields are mapped by the json tags, blank lines are skipped.
+// r is read through a buffer as lines are pulled, so r must not be read elsewhere meanwhile,
+// a second terminal operator continues at the next line and line numbers keep counting from the first.
+// the enumeration ends at the first error, err returns it afterwards, *RecordError when a line can not be decoded
+func StreamJSONLines(r io.Reader, sample interface{}) (en Enumerable, err func() error) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		panic("sample is nil")
+	}
+	br := bufio.NewReader(r)
+	var (
+		line  int
+		first error
+	)
+	en = &_enumerable{
+		elementType: t,
+		iterate: func(done <-chan struct{}) iterator {
+			return func() (reflect.Value, bool) {
+				for first == nil {
+					select {
+					case <-done:
+						return reflect.Value{}, false
+					default:
+					}
+					b, e := br.ReadBytes('\n')
+					if e != nil && e != io.EOF {
+						first = e
+						break
+					}
+					if len(b) == 0 && e == io.EOF {
+						break
+					}
+					line++
+					if b = bytes.TrimSpace(b); len(b) == 0 {
+						continue
+					}
+					ptr := reflect.New(t)
+					if e := json.Unmarshal(b, ptr.Interface()); e != nil {
+						first = &RecordError{Line: line, Err: e}
+						break
+					}
+					return ptr.Elem(), true
+				}
+				return reflect.Value{}, false
+			}
+		},
+	}
+	return en, func() error { return first }
+}
+
+// FromJSONLines reads all the lines of r into an Array of sample's type, see StreamJSONLines.
+// the Array holds the lines read before the error when err is not nil
+func FromJSONLines(r io.Reader, sample interface{}) (Array, error) {
+	en, err := StreamJSONLines(r, sample)
+	arr := en.ToArray()
+	return arr, err()
+}
+
+func (p *_enumerable) WriteJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	next := p.iterate(nil)
+	for v, ok := next(); ok; v, ok = next() {
+		if err := enc.Encode(v.Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *_array) WriteJSONLines(w io.Writer) error {
+	return p.AsEnumerable().WriteJSONLines(w)
+}
@@ -0,0 +1,72 @@
+package lambda
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type event struct {
+	Kind   string  `json:"kind"`
+	Amount float64 `json:"amount"`
+	User   *struct {
+		Name string `json:"name"`
+	} `json:"user,omitempty"`
+}
+
+const events = `{"kind": "buy", "amount": 10, "user": {"name": "Abraham"}}
+
+{"kind": "sell", "amount": 4.5}
+{"kind": "buy", "amount": 2, "user": {"name": "Edith"}}`
+
+func TestFromJSONLines(t *testing.T) {
+	defer report(t, time.Now())
+	arr, err := FromJSONLines(strings.NewReader(events), event{})
+	isTrue(t, err == nil)
+	isTrue(t, arr.Count(nil) == 3)
+	isTrue(t, arr.Filter(func(e event) bool { return e.Kind == "buy" }).Sum(Field("Amount")) == float64(12))
+	isTrue(t, fmt.Sprint(arr.Map(Field("User.Name")).Pointer()) == "[Abraham  Edith]")
+
+	docs, err := FromJSONLines(strings.NewReader(events), map[string]interface{}{})
+	isTrue(t, err == nil)
+	isTrue(t, docs.Sum(JSONPath("$.amount").AsFloat()) == 16.5)
+
+	arr, err = FromJSONLines(strings.NewReader("{\"kind\": \"buy\"}\n\n{\"kind\": 1}\n{}"), event{})
+	var re *RecordError
+	isTrue(t, errors.As(err, &re) && re.Line == 3 && re.Column == "")
+	isTrue(t, arr.Count(nil) == 1)
+}
+
+func TestStreamJSONLines(t *testing.T) {
+	defer report(t, time.Now())
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, "{\"kind\": \"k%d\", \"amount\": %d}\n", i%4, i)
+	}
+	r := &countingReader{r: strings.NewReader(b.String())}
+	en, err := StreamJSONLines(r, &event{})
+	isTrue(t, en.Any(func(e *event) bool { return e.Amount == 10 }))
+	isTrue(t, err() == nil)
+	isTrue(t, r.n < b.Len())
+
+	var out bytes.Buffer
+	isTrue(t, en.Filter(func(e *event) bool { return e.Kind == "k3" }).Take(0, 2).WriteJSONLines(&out) == nil)
+	isTrue(t, out.String() == "{\"kind\":\"k3\",\"amount\":11}\n{\"kind\":\"k3\",\"amount\":15}\n")
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	defer report(t, time.Now())
+	arr, _ := FromJSONLines(strings.NewReader(events), event{})
+	var out bytes.Buffer
+	isTrue(t, arr.OrderBy(Field("Amount")).WriteJSONLines(&out) == nil)
+	back, err := FromJSONLines(&out, event{})
+	isTrue(t, err == nil)
+	isTrue(t, fmt.Sprint(back.Map(Field("Amount")).Pointer()) == "[2 4.5 10]")
+
+	out.Reset()
+	isTrue(t, LambdaArray([]int{1, 2}).WriteJSONLines(&out) == nil)
+	isTrue(t, out.String() == "1\n2\n")
+}
@@ -0,0 +1,274 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// make Dictionary from source(map[K]V type)
+func LambdaMap(source interface{}, options ...MapOptions) Dictionary {
+	t := reflect.TypeOf(source)
+	if t == nil || t.Kind() != reflect.Map {
+		panic(fmt.Errorf("source type is %v, not map ", t))
+	}
+	m := _map{source, t, reflect.ValueOf(source), false}
+	for _, o := range options {
+		m.sortKeys = m.sortKeys || o.SortKeys
+	}
+	return &m
+}
+
+type MapOptions struct {
+	// iterate by key order, keys are compared by BasicComparator
+	// make results reproducible
+	SortKeys bool
+}
+
+// the element of Dictionary.ToArray
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
+type Dictionary interface {
+
+	// map filter
+	// eg: m.Filter(func(k string, v int) bool{ return v>10}), m.Filter("(k, v) => v > 10")
+	Filter(express interface{}) Dictionary
+
+	// map values to new Dictionary with the same keys
+	// express func(v V) R{ return R }
+	MapValues(express interface{}) Dictionary
+
+	// keys of map, Keys().Pointer().([]K)
+	Keys() Array
+
+	// values of map, Values().Pointer().([]V)
+	Values() Array
+
+	// Determines whether any entry satisfies the condition
+	// express func(k K, v V) bool, nil returns true when the map is not empty
+	Any(express interface{}) bool
+
+	// Determines whether the condition is satisfied for all entries
+	All(express interface{}) bool
+
+	// Returns a number indicating how many entries satisfy the condition
+	Count(express interface{}) int
+
+	// entry with the maximum value returned by the expression
+	// express func(k K, v V) TOut, TOut must be number Type or Compare, nil compares the values
+	Max(express interface{}) KeyValue
+
+	// entry with the minimum value returned by the expression
+	Min(express interface{}) KeyValue
+
+	// Determines whether the map contains the key
+	ContainsKey(key interface{}) bool
+
+	// Determines whether the map contains the specified value
+	// express is func(v V) bool, number type or implements Compare or Equal, same as Array.Contains
+	ContainsValue(express interface{}) bool
+
+	// entries to Array, ToArray().Pointer().([]KeyValue)
+	ToArray() Array
+
+	// map pointer
+	// Dictionary.Pointer().(map[K]V)
+	Pointer() interface{}
+}
+
+type _map struct {
+
+	// source map
+	source interface{}
+	// map type
+	mapType reflect.Type
+	// value of map
+	value reflect.Value
+	// iterate by key order
+	sortKeys bool
+}
+
+func (p *_map) Len() int {
+	return p.value.Len()
+}
+
+func (p *_map) keys() []reflect.Value {
+	keys := p.value.MapKeys()
+	if p.sortKeys {
+		sort.SliceStable(keys, func(i, j int) bool {
+			tor, err := BasicComparator(keys[i].Interface())
+			if err != nil {
+				panic(err)
+			}
+			return tor.CompareTo(keys[j].Interface()) < 0
+		})
+	}
+	return keys
+}
+
+// iterate entries, in key order when sortKeys
+func (p *_map) EachKV(fn func(k, v reflect.Value)) {
+	for _, k := range p.keys() {
+		fn(k, p.value.MapIndex(k))
+	}
+}
+
+func (p *_map) predicate(express interface{}) func(k, v reflect.Value) bool {
+	express = bindEntry(express, p.mapType.Key(), p.mapType.Elem())
+	checkExpress(
+		reflect.TypeOf(express),
+		[]reflect.Type{p.mapType.Key(), p.mapType.Elem()},
+		[]reflect.Type{reflect.TypeOf(true)})
+	fn := reflect.ValueOf(express)
+	return func(k, v reflect.Value) bool {
+		return fn.Call([]reflect.Value{k, v})[0].Interface().(bool)
+	}
+}
+
+func (p *_map) with(value reflect.Value) Dictionary {
+	return &_map{value.Interface(), value.Type(), value, p.sortKeys}
+}
+
+func (p *_map) Filter(express interface{}) Dictionary {
+	match := p.predicate(express)
+	ret := reflect.MakeMap(p.mapType)
+	p.EachKV(func(k, v reflect.Value) {
+		if match(k, v) {
+			ret.SetMapIndex(k, v)
+		}
+	})
+	return p.with(ret)
+}
+
+func (p *_map) MapValues(express interface{}) Dictionary {
+	express = bindExpress(express, p.mapType.Elem())
+	ot := checkExpressRARTO(express, []reflect.Type{p.mapType.Elem()})
+	fn := reflect.ValueOf(express)
+	ret := reflect.MakeMap(reflect.MapOf(p.mapType.Key(), ot))
+	p.EachKV(func(k, v reflect.Value) {
+		ret.SetMapIndex(k, fn.Call([]reflect.Value{v})[0])
+	})
+	return p.with(ret)
+}
+
+func (p *_map) Keys() Array {
+	ret := reflect.MakeSlice(reflect.SliceOf(p.mapType.Key()), 0, p.Len())
+	ret = reflect.Append(ret, p.keys()...)
+	return innerLambdaArray(ret)
+}
+
+func (p *_map) Values() Array {
+	ret := reflect.MakeSlice(reflect.SliceOf(p.mapType.Elem()), 0, p.Len())
+	p.EachKV(func(_, v reflect.Value) {
+		ret = reflect.Append(ret, v)
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_map) Any(express interface{}) bool {
+	if express == nil {
+		return p.Len() > 0
+	}
+	match := p.predicate(express)
+	for _, k := range p.keys() {
+		if match(k, p.value.MapIndex(k)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *_map) All(express interface{}) bool {
+	if express == nil {
+		return p.Len() > 0
+	}
+	match := p.predicate(express)
+	for _, k := range p.keys() {
+		if !match(k, p.value.MapIndex(k)) {
+			return false
+		}
+	}
+	return p.Len() > 0
+}
+
+func (p *_map) Count(express interface{}) int {
+	if express == nil {
+		return p.Len()
+	}
+	match := p.predicate(express)
+	count := 0
+	p.EachKV(func(k, v reflect.Value) {
+		if match(k, v) {
+			count++
+		}
+	})
+	return count
+}
+
+func (p *_map) maxOrMin(express interface{}, isMax bool) KeyValue {
+	if express != nil {
+		express = bindEntry(express, p.mapType.Key(), p.mapType.Elem())
+		checkExpressRARTO(express, []reflect.Type{p.mapType.Key(), p.mapType.Elem()})
+	}
+	funcValue := reflect.ValueOf(express)
+	f := func(k, v reflect.Value) interface{} {
+		if express == nil {
+			return v.Interface()
+		}
+		return funcValue.Call([]reflect.Value{k, v})[0].Interface()
+	}
+
+	var m KeyValue
+	var mc interface{}
+	first := true
+	p.EachKV(func(k, v reflect.Value) {
+		vc := f(k, v)
+		if first {
+			m, mc, first = KeyValue{k.Interface(), v.Interface()}, vc, false
+			return
+		}
+		tor, err := BasicComparator(vc)
+		if err != nil {
+			panic(err)
+		}
+		if c := tor.CompareTo(mc); (isMax && c > 0) || (!isMax && c < 0) {
+			m, mc = KeyValue{k.Interface(), v.Interface()}, vc
+		}
+	})
+	return m
+}
+
+func (p *_map) Max(express interface{}) KeyValue {
+	return p.maxOrMin(express, true)
+}
+
+func (p *_map) Min(express interface{}) KeyValue {
+	return p.maxOrMin(express, false)
+}
+
+func (p *_map) ContainsKey(key interface{}) bool {
+	k := reflect.ValueOf(key)
+	if !k.IsValid() || k.Type() != p.mapType.Key() {
+		return false
+	}
+	return p.value.MapIndex(k).IsValid()
+}
+
+func (p *_map) ContainsValue(express interface{}) bool {
+	return p.Values().Contains(express)
+}
+
+func (p *_map) ToArray() Array {
+	ret := make([]KeyValue, 0, p.Len())
+	p.EachKV(func(k, v reflect.Value) {
+		ret = append(ret, KeyValue{k.Interface(), v.Interface()})
+	})
+	return LambdaArray(ret)
+}
+
+func (p *_map) Pointer() interface{} {
+	return p.value.Interface()
+}
@@ -0,0 +1,76 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func makeAgeMap() map[string]int {
+	return map[string]int{
+		"Abraham": 20,
+		"Edith":   25,
+		"Charles": 40,
+		"Anthony": 26,
+		"Abel":    33,
+	}
+}
+
+func Test__map_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	m := LambdaMap(makeAgeMap())
+	ret := m.Filter(func(name string, age int) bool { return age > 25 }).Pointer().(map[string]int)
+	isTrue(t, len(ret) == 3 && ret["Charles"] == 40)
+
+	next := m.MapValues(func(age int) int { return age + 1 }).Pointer().(map[string]int)
+	isTrue(t, next["Abel"] == 34)
+	next = m.MapValues("age => age + 1").Pointer().(map[string]int)
+	isTrue(t, next["Abel"] == 34)
+}
+
+func Test__map_Keys(t *testing.T) {
+	defer report(t, time.Now())
+	m := LambdaMap(makeAgeMap(), MapOptions{SortKeys: true})
+	keys := m.Keys().Pointer().([]string)
+	isTrue(t, fmt.Sprint(keys) == "[Abel Abraham Anthony Charles Edith]")
+	values := m.Values().Pointer().([]int)
+	isTrue(t, fmt.Sprint(values) == "[33 20 26 40 25]")
+
+	kvs := m.ToArray().Pointer().([]KeyValue)
+	isTrue(t, kvs[0].Key == "Abel" && kvs[0].Value == 33)
+}
+
+func Test__map_Any(t *testing.T) {
+	defer report(t, time.Now())
+	m := LambdaMap(makeAgeMap())
+	isTrue(t, m.Any(nil))
+	isTrue(t, m.Any(func(name string, age int) bool { return age > 30 }))
+	isFalse(t, m.All(func(name string, age int) bool { return age > 30 }))
+	isTrue(t, m.Count(func(name string, age int) bool { return age > 30 }) == 2)
+	isTrue(t, m.ContainsKey("Edith"))
+	isFalse(t, m.ContainsKey("jack"))
+	isTrue(t, m.ContainsValue(26))
+	isFalse(t, LambdaMap(map[int]user{}).Any(nil))
+
+	isTrue(t, m.Any("(name, age) => age > 30"))
+	isFalse(t, m.All(Lambda("(name, age) => age > 30")))
+	isTrue(t, m.Count("(name, age) => startsWith(name, \"A\")") == 3)
+	isTrue(t, len(m.Filter("(name, age) => age > 25").Pointer().(map[string]int)) == 3)
+	docs := LambdaMap(map[string]interface{}{
+		"a": map[string]interface{}{"n": 1.0},
+		"b": map[string]interface{}{"n": 3.0},
+	})
+	isTrue(t, docs.Count(JSONPath("$.n").Gt(2)) == 1)
+	isTrue(t, docs.Max(JSONPath("$.n")).Key == "b")
+}
+
+func Test__map_Max(t *testing.T) {
+	defer report(t, time.Now())
+	m := LambdaMap(makeAgeMap())
+	isTrue(t, m.Max(nil).Key == "Charles")
+	isTrue(t, m.Min(func(name string, age int) int { return age }).Key == "Abraham")
+
+	users := LambdaMap(map[int]user{1: {"Abraham", 20}, 2: {"Charles", 40}})
+	isTrue(t, users.Max(func(id int, u user) int { return u.age }).Key == 2)
+	isTrue(t, users.ContainsValue(user{"Abraham", 20}))
+}
@@ -0,0 +1,106 @@
+package lambda
+
+import (
+	"reflect"
+	"sort"
+)
+
+// OrderedArray is the Array sorted by OrderBy,
+// it can be sorted by further keys with ThenBy and ThenByDescending
+type OrderedArray interface {
+	Array
+
+	// subsequent ascending ordering of the elements with equal keys
+	// express func(ele T) K, K must be number Type, string or Compare
+	ThenBy(express interface{}) OrderedArray
+
+	// subsequent descending ordering of the elements with equal keys
+	ThenByDescending(express interface{}) OrderedArray
+}
+
+type _ordered struct {
+	// sorted elements
+	*_array
+	// elements before sorting
+	source *_array
+	// indexes in source of the sorted elements
+	index []int
+	// bounds of the runs of elements with equal keys, run r is index[runs[r]:runs[r+1]]
+	runs []int
+}
+
+func (p *_array) OrderBy(express interface{}) OrderedArray {
+	return p.unordered().thenBy(express, false)
+}
+
+func (p *_array) OrderByDescending(express interface{}) OrderedArray {
+	return p.unordered().thenBy(express, true)
+}
+
+func (p *_ordered) ThenBy(express interface{}) OrderedArray {
+	return p.thenBy(express, false)
+}
+
+func (p *_ordered) ThenByDescending(express interface{}) OrderedArray {
+	return p.thenBy(express, true)
+}
+
+// the elements in a single run
+func (p *_array) unordered() *_ordered {
+	index := make([]int, p.Len())
+	for i := range index {
+		index[i] = i
+	}
+	return &_ordered{p, p, index, []int{0, len(index)}}
+}
+
+// stable sort of every run by the key, the key of every element is evaluated once,
+// the keys before are neither evaluated nor compared again
+func (p *_ordered) thenBy(express interface{}, desc bool) OrderedArray {
+	source := p.source
+	express = bindExpress(express, source.elementType)
+	checkExpressRARTO(express, []reflect.Type{source.elementType})
+	fn := reflect.ValueOf(express)
+	keys := make([]Compare, source.Len())
+	source.EachV(func(v reflect.Value, i int) {
+		tor, err := BasicComparator(fn.Call([]reflect.Value{v})[0].Interface())
+		if err != nil {
+			panic(err)
+		}
+		keys[i] = tor
+	})
+
+	index := append([]int(nil), p.index...)
+	runs := []int{0}
+	for r := 0; r+1 < len(p.runs); r++ {
+		from, to := p.runs[r], p.runs[r+1]
+		run := index[from:to]
+		sort.SliceStable(run, func(a, b int) bool {
+			c := keys[run[a]].CompareTo(keyOf(keys[run[b]]))
+			return c != 0 && (c < 0) != desc
+		})
+		for i := from + 1; i < to; i++ {
+			if keys[index[i]].CompareTo(keyOf(keys[index[i-1]])) != 0 {
+				runs = append(runs, i)
+			}
+		}
+		runs = append(runs, to)
+	}
+
+	ret := reflect.MakeSlice(reflect.SliceOf(source.elementType), len(index), len(index))
+	for i, j := range index {
+		ret.Index(i).Set(source.value.Index(j))
+	}
+	return &_ordered{innerLambdaArray(ret).(*_array), source, index, runs}
+}
+
+// the value compared by CompareTo
+func keyOf(c Compare) interface{} {
+	switch b := c.(type) {
+	case *BasicCompare:
+		return b.v
+	case *TaggedCompare:
+		return b.v.Interface()
+	}
+	return c
+}
@@ -0,0 +1,152 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ErrOverflow is raised by Sum with Checked or Widen when the sum of integers overflows
+var ErrOverflow = errors.New("integer overflow")
+
+// Overflow is the policy of SumWith and AverageWith for sums of integers
+type Overflow int
+
+const (
+	// sum in the type of the values, the sum wraps around on overflow like Sum
+	Wrap Overflow = iota
+	// sum int kinds in int64, uint kinds in uint64 and floats in float64
+	// panics with ErrOverflow when int64 or uint64 overflows
+	Widen
+	// sum in the type of the values, panics with ErrOverflow on overflow
+	Checked
+	// sum in the type of the values, the sum stays at the max or min value on overflow
+	Saturate
+)
+
+type SumOptions struct {
+	// overflow policy, default Wrap
+	Overflow Overflow
+}
+
+// adder of type t by the overflow policy
+func (o SumOptions) adder(t reflect.Type) Add {
+	if o.Overflow == Wrap || registered(t) != nil {
+		return Adder(t)
+	}
+	var add Add
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if o.Overflow == Widen {
+			t = reflect.TypeOf(int64(0))
+		}
+		add = &_signed{t: t, policy: o.Overflow}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if o.Overflow == Widen {
+			t = reflect.TypeOf(uint64(0))
+		}
+		add = &_unsigned{t: t, policy: o.Overflow}
+	case reflect.Float32:
+		if o.Overflow == Widen {
+			add = &_widenFloat{}
+			break
+		}
+		return Adder(t)
+	default:
+		return Adder(t)
+	}
+	add.SetZero()
+	return add
+}
+
+// overflow of the sum in type t, the sum is saturated or panics by policy
+func overflow(t reflect.Type, policy Overflow) {
+	if policy != Saturate {
+		panic(fmt.Errorf("%w: sum of %s", ErrOverflow, t.String()))
+	}
+}
+
+type _signed struct {
+	t      reflect.Type
+	policy Overflow
+	v      int64
+}
+
+func (s *_signed) Add(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		panic(fmt.Sprintf("not support %s add %s", s.t.String(), value.Type().String()))
+	}
+	max := int64(math.MaxInt64) >> (64 - s.t.Bits())
+	min := -max - 1
+	x := value.Int()
+	switch {
+	case x > 0 && s.v > max-x:
+		overflow(s.t, s.policy)
+		s.v = max
+	case x < 0 && s.v < min-x:
+		overflow(s.t, s.policy)
+		s.v = min
+	default:
+		s.v += x
+	}
+}
+
+func (s *_signed) Value() interface{} {
+	return reflect.ValueOf(s.v).Convert(s.t).Interface()
+}
+
+func (s *_signed) SetZero() {
+	s.v = 0
+}
+
+type _unsigned struct {
+	t      reflect.Type
+	policy Overflow
+	v      uint64
+}
+
+func (u *_unsigned) Add(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		panic(fmt.Sprintf("not support %s add %s", u.t.String(), value.Type().String()))
+	}
+	max := uint64(math.MaxUint64) >> (64 - u.t.Bits())
+	if x := value.Uint(); u.v > max-x {
+		overflow(u.t, u.policy)
+		u.v = max
+	} else {
+		u.v += x
+	}
+}
+
+func (u *_unsigned) Value() interface{} {
+	return reflect.ValueOf(u.v).Convert(u.t).Interface()
+}
+
+func (u *_unsigned) SetZero() {
+	u.v = 0
+}
+
+// sum of float32 in float64
+type _widenFloat struct {
+	v float64
+}
+
+func (f *_widenFloat) Add(value reflect.Value) {
+	if value.Kind() != reflect.Float32 {
+		panic(fmt.Sprintf("not support float64 add %s", value.Type().String()))
+	}
+	f.v += value.Float()
+}
+
+func (f *_widenFloat) Value() interface{} {
+	return f.v
+}
+
+func (f *_widenFloat) SetZero() {
+	f.v = 0
+}
@@ -0,0 +1,80 @@
+package lambda
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func makeBytes(n int) []uint8 {
+	bytes := make([]uint8, n)
+	for i := range bytes {
+		bytes[i] = 200
+	}
+	return bytes
+}
+
+func TestOverflow_Sum(t *testing.T) {
+	defer report(t, time.Now())
+	bytes := LambdaArray(makeBytes(300))
+	isTrue(t, bytes.Sum(nil) == uint8(60000%256))
+	isTrue(t, bytes.SumWith(nil, SumOptions{Overflow: Widen}) == uint64(60000))
+	isTrue(t, bytes.AverageWith(nil, SumOptions{Overflow: Widen}) == 200)
+	isTrue(t, bytes.SumWith(nil, SumOptions{Overflow: Saturate}) == uint8(255))
+	isTrue(t, LambdaArray([]uint8{1, 2}).SumWith(nil, SumOptions{Overflow: Checked}) == uint8(3))
+
+	small := LambdaArray([]int8{100, 100, -50, -120, -120})
+	isTrue(t, small.SumWith(nil, SumOptions{Overflow: Widen}) == int64(-90))
+	// 127 after saturation, then 77, -43, -128
+	isTrue(t, small.SumWith(nil, SumOptions{Overflow: Saturate}) == int8(-128))
+	isTrue(t, LambdaArray([]int{math.MaxInt64, 1}).SumWith(nil, SumOptions{Overflow: Saturate}) == math.MaxInt64)
+	isTrue(t, LambdaArray([]uint{math.MaxUint64, 1}).SumWith(nil, SumOptions{Overflow: Saturate}) == uint(math.MaxUint64))
+	isTrue(t, LambdaArray([]float32{1.5, 2.5}).SumWith(nil, SumOptions{Overflow: Widen}) == float64(4))
+	isTrue(t, LambdaArray([]float32{1.5, 2.5}).SumWith(nil, SumOptions{Overflow: Checked}) == float32(4))
+
+	us := []user{{"Abraham", 20}, {"Edith", 25}}
+	isTrue(t, LambdaArray(us).SumWith(func(u user) int16 { return int16(u.age) * 1000 }, SumOptions{Overflow: Widen}) == int64(45000))
+	isTrue(t, LambdaArray([]int16{}).SumWith(nil, SumOptions{Overflow: Widen}) == int64(0))
+}
+
+func TestOverflow_Kinds(t *testing.T) {
+	defer report(t, time.Now())
+	type myInt int32
+	sources := []interface{}{
+		[]int{1, 2, 3}, []int8{1, 2, 3}, []int16{1, 2, 3}, []int32{1, 2, 3}, []int64{1, 2, 3},
+		[]uint{1, 2, 3}, []uint8{1, 2, 3}, []uint16{1, 2, 3}, []uint32{1, 2, 3}, []uint64{1, 2, 3},
+		[]uintptr{1, 2, 3}, []myInt{1, 2, 3}, []float32{1, 2, 3}, []float64{1, 2, 3},
+	}
+	for _, source := range sources {
+		arr := LambdaArray(source)
+		for _, policy := range []Overflow{Wrap, Widen, Checked, Saturate} {
+			sum := arr.SumWith(nil, SumOptions{Overflow: policy})
+			if reflect.ValueOf(sum).Convert(reflect.TypeOf(float64(0))).Float() != 6 {
+				t.Errorf("sum of %T by %d is %v", source, policy, sum)
+			}
+			isTrue(t, arr.AverageWith(nil, SumOptions{Overflow: policy}) == 2)
+		}
+		isTrue(t, reflect.TypeOf(arr.Sum(nil)) == reflect.TypeOf(source).Elem())
+	}
+	isTrue(t, LambdaArray([]int32{math.MaxInt32, 1}).Sum(nil) == int32(math.MinInt32))
+	isTrue(t, LambdaArray([]uint{math.MaxUint64, 2}).Sum(nil) == uint(1))
+	isTrue(t, LambdaArray([]myInt{1, 2}).Sum(nil) == myInt(3))
+}
+
+func TestOverflow_Checked(t *testing.T) {
+	defer report(t, time.Now())
+	_, err := TryLambdaArray(makeBytes(2)).SumWith(nil, SumOptions{Overflow: Checked})
+	isTrue(t, errors.Is(err, ErrOverflow))
+	_, err = TryLambdaArray([]int64{math.MinInt64, -1}).AverageWith(nil, SumOptions{Overflow: Widen})
+	isTrue(t, errors.Is(err, ErrOverflow))
+	v, err := TryLambdaArray(makeBytes(2)).SumWith(nil, SumOptions{Overflow: Widen})
+	isTrue(t, err == nil && v == uint64(400))
+
+	defer func() {
+		err, _ := recover().(error)
+		isTrue(t, errors.Is(err, ErrOverflow))
+	}()
+	LambdaArray([]int32{math.MaxInt32, 1}).SumWith(nil, SumOptions{Overflow: Checked})
+}
@@ -0,0 +1,220 @@
+package lambda
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelArray executes the operators by a fixed pool of workers,
+// the array is split into chunks and each worker processes one chunk at a time
+type ParallelArray interface {
+
+	// Filter keeps the input order of elements
+	AsOrdered() ParallelArray
+
+	// parallel filter, the output order is the order chunks complete unless AsOrdered
+	Filter(express interface{}) Array
+
+	// parallel map, the output keeps the input order
+	Map(express interface{}) Array
+
+	// parallel Count
+	Count(express interface{}) int
+
+	// parallel Any, workers stop once an element satisfies the condition
+	Any(express interface{}) bool
+
+	// parallel Sum
+	Sum(express interface{}) interface{}
+
+	// parallel Max, same result as Array.Max
+	Max(express interface{}) interface{}
+
+	// parallel Min, same result as Array.Min
+	Min(express interface{}) interface{}
+}
+
+type _parallel struct {
+	*_array
+	// worker count
+	workers int
+	// keep input order
+	ordered bool
+}
+
+func (p *_array) AsParallel(workers int) ParallelArray {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &_parallel{p.slice(), workers, false}
+}
+
+func (p *_parallel) AsOrdered() ParallelArray {
+	return &_parallel{p._array, p.workers, true}
+}
+
+// chunk count of the array
+func (p *_parallel) chunks() int {
+	length := p.Len()
+	n := p.workers * 4
+	if n > length {
+		n = length
+	}
+	return n
+}
+
+// run fn for every chunk by the workers
+// fn is called with chunk index and element range [from, to)
+// a panic in fn is raised again in the caller
+func (p *_parallel) run(fn func(chunk, from, to int)) {
+	length, n := p.Len(), p.chunks()
+	tasks := make(chan int, n)
+	for i := 0; i < n; i++ {
+		tasks <- i
+	}
+	close(tasks)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var err interface{}
+	for w := 0; w < p.workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() { err = r })
+				}
+			}()
+			for c := range tasks {
+				fn(c, c*length/n, (c+1)*length/n)
+			}
+		}()
+	}
+	wg.Wait()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (p *_parallel) predicate(express interface{}) reflect.Value {
+	express = bindExpress(express, p.elementType)
+	checkExpress(
+		reflect.TypeOf(express),
+		[]reflect.Type{p.elementType},
+		[]reflect.Type{reflect.TypeOf(true)})
+	return reflect.ValueOf(express)
+}
+
+func (p *_parallel) Filter(express interface{}) Array {
+	fn := p.predicate(express)
+	st := reflect.SliceOf(p.elementType)
+	parts := make([]reflect.Value, p.chunks())
+	var completed int32
+	p.run(func(c, from, to int) {
+		part := reflect.MakeSlice(st, 0, 0)
+		for i := from; i < to; i++ {
+			if v := p.value.Index(i); fn.Call([]reflect.Value{v})[0].Interface().(bool) {
+				part = reflect.Append(part, v)
+			}
+		}
+		if !p.ordered {
+			c = int(atomic.AddInt32(&completed, 1)) - 1
+		}
+		parts[c] = part
+	})
+	ret := reflect.MakeSlice(st, 0, 0)
+	for _, part := range parts {
+		ret = reflect.AppendSlice(ret, part)
+	}
+	return innerLambdaArray(ret)
+}
+
+func (p *_parallel) Map(express interface{}) Array {
+	express = bindExpress(express, p.elementType)
+	ot := checkExpressRARTO(express, []reflect.Type{p.elementType})
+	fn := reflect.ValueOf(express)
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), p.Len(), p.Len())
+	p.run(func(_, from, to int) {
+		for i := from; i < to; i++ {
+			ret.Index(i).Set(fn.Call([]reflect.Value{p.value.Index(i)})[0])
+		}
+	})
+	return innerLambdaArray(ret)
+}
+
+func (p *_parallel) Count(express interface{}) int {
+	if express == nil {
+		return p.Len()
+	}
+	fn := p.predicate(express)
+	var count int64
+	p.run(func(_, from, to int) {
+		n := int64(0)
+		for i := from; i < to; i++ {
+			if fn.Call([]reflect.Value{p.value.Index(i)})[0].Interface().(bool) {
+				n++
+			}
+		}
+		atomic.AddInt64(&count, n)
+	})
+	return int(count)
+}
+
+func (p *_parallel) Any(express interface{}) bool {
+	if express == nil {
+		return p.Len() > 0
+	}
+	fn := p.predicate(express)
+	var found int32
+	p.run(func(_, from, to int) {
+		for i := from; i < to && atomic.LoadInt32(&found) == 0; i++ {
+			if fn.Call([]reflect.Value{p.value.Index(i)})[0].Interface().(bool) {
+				atomic.StoreInt32(&found, 1)
+			}
+		}
+	})
+	return found == 1
+}
+
+func (p *_parallel) Sum(express interface{}) interface{} {
+	if p.Len() == 0 {
+		return p._array.Sum(express)
+	}
+	parts := make([]interface{}, p.chunks())
+	p.run(func(c, from, to int) {
+		parts[c] = innerLambdaArray(p.value.Slice(from, to)).Sum(express)
+	})
+	add := Adder(reflect.TypeOf(parts[0]))
+	for _, part := range parts {
+		add.Add(reflect.ValueOf(part))
+	}
+	return add.Value()
+}
+
+// max or min of every chunk, then max or min of the chunk results in chunk order
+// so the first element wins on equal keys like Array.Max
+func (p *_parallel) maxOrMin(express interface{}, isMax bool) interface{} {
+	if p.Len() == 0 {
+		return p._array.maxOrMin(express, isMax)
+	}
+	parts := make([]interface{}, p.chunks())
+	p.run(func(c, from, to int) {
+		parts[c] = innerLambdaArray(p.value.Slice(from, to)).(*_array).maxOrMin(express, isMax)
+	})
+	ret := reflect.MakeSlice(reflect.SliceOf(p.elementType), len(parts), len(parts))
+	for i, part := range parts {
+		ret.Index(i).Set(reflect.ValueOf(part))
+	}
+	return innerLambdaArray(ret).(*_array).maxOrMin(express, isMax)
+}
+
+func (p *_parallel) Max(express interface{}) interface{} {
+	return p.maxOrMin(express, true)
+}
+
+func (p *_parallel) Min(express interface{}) interface{} {
+	return p.maxOrMin(express, false)
+}
@@ -0,0 +1,52 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test__parallel_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeIntArray())
+	ret := arr.AsParallel(4).Filter(func(e int) bool { return e%3 == 0 })
+	isTrue(t, ret.Count(nil) == count/3)
+	isTrue(t, ret.Sum(nil) == arr.Filter(func(e int) bool { return e%3 == 0 }).Sum(nil))
+
+	ordered := arr.AsParallel(4).AsOrdered().Filter(func(e int) bool { return e%3 == 0 }).Pointer().([]int)
+	for i := 1; i < len(ordered); i++ {
+		isTrue(t, ordered[i-1] < ordered[i])
+	}
+
+	small := LambdaArray([3]int{1, 2, 3}).AsParallel(8).AsOrdered().Filter(func(e int) bool { return e > 1 }).Pointer()
+	isTrue(t, fmt.Sprint(small) == "[2 3]")
+}
+
+func Test__parallel_Map(t *testing.T) {
+	defer report(t, time.Now())
+	ret := LambdaArray(makeIntArray()).AsParallel(0).Map(func(e int) string { return fmt.Sprint(e) }).Pointer().([]string)
+	isTrue(t, len(ret) == count && ret[0] == "1" && ret[count-1] == fmt.Sprint(count))
+	empty := LambdaArray([]int{}).AsParallel(4).Map(func(e int) int { return e }).Pointer().([]int)
+	isTrue(t, len(empty) == 0)
+}
+
+func Test__parallel_Count(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeUserArray()).AsParallel(4)
+	isTrue(t, arr.Count(func(u user) bool { return u.age%2 == 0 }) == count/2)
+	isTrue(t, arr.Any(func(u user) bool { return u.name == "un:1997" }))
+	isFalse(t, arr.Any(func(u user) bool { return u.age < 0 }))
+}
+
+func Test__parallel_Sum(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeUserArray())
+	isTrue(t, arr.AsParallel(4).Sum(func(u user) int { return u.age }) == arr.Sum(func(u user) int { return u.age }))
+	isTrue(t, LambdaArray([]int{}).AsParallel(4).Sum(nil) == 0)
+
+	us := append(makeUsers(), user{"Alice", 40})
+	par := LambdaArray(us).AsParallel(3)
+	isTrue(t, par.Max(func(u user) int { return u.age }).(user).name == "Charles")
+	isTrue(t, par.Min(func(u user) int { return u.age }).(user).name == "Abraham")
+	isTrue(t, LambdaArray(makeIntArray()).AsParallel(4).Max(nil) == count)
+}
@@ -0,0 +1,63 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// check the element type of other is the element type of p
+func (p *_array) sameElement(other Array) *_array {
+	o := asInner(other)
+	if o.elementType != p.elementType {
+		panic(fmt.Sprintf("element type[%s] is not %s.", o.elementType.String(), p.elementType.String()))
+	}
+	return o
+}
+
+// elements of p whose key passes keep, in order of first occurrence, one element per key
+func (p *_array) distinct(keyFn func(v reflect.Value) reflect.Value, keyType reflect.Type, keep func(key interface{}) bool) Array {
+	x := newIndex(keyType)
+	ret := reflect.MakeSlice(reflect.SliceOf(p.elementType), 0, 0)
+	p.EachV(func(v reflect.Value, i int) {
+		k := keyFn(v).Interface()
+		if keep(k) && x.add(k, i) {
+			ret = reflect.Append(ret, v)
+		}
+	})
+	return innerLambdaArray(ret)
+}
+
+func identity(v reflect.Value) reflect.Value {
+	return v
+}
+
+func (p *_array) Distinct() Array {
+	return p.distinct(identity, p.elementType, func(interface{}) bool { return true })
+}
+
+func (p *_array) DistinctBy(express interface{}) Array {
+	express = bindExpress(express, p.elementType)
+	kt := checkExpressRARTO(express, []reflect.Type{p.elementType})
+	return p.distinct(callKey(reflect.ValueOf(express)), kt, func(interface{}) bool { return true })
+}
+
+func (p *_array) Union(other Array) Array {
+	o := p.sameElement(other)
+	all := reflect.MakeSlice(reflect.SliceOf(p.elementType), 0, p.Len()+o.Len())
+	for _, arr := range []*_array{p, o} {
+		arr.EachV(func(v reflect.Value, _ int) {
+			all = reflect.Append(all, v)
+		})
+	}
+	return asInner(innerLambdaArray(all)).Distinct()
+}
+
+func (p *_array) Intersect(other Array) Array {
+	o := p.sameElement(other).indexBy(identity, p.elementType)
+	return p.distinct(identity, p.elementType, func(k interface{}) bool { return o.find(k) >= 0 })
+}
+
+func (p *_array) Except(other Array) Array {
+	o := p.sameElement(other).indexBy(identity, p.elementType)
+	return p.distinct(identity, p.elementType, func(k interface{}) bool { return o.find(k) < 0 })
+}
@@ -0,0 +1,171 @@
+package lambda
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// values returned by express as float64, the elements when express is nil
+func (p *_array) floats(express interface{}) []float64 {
+	values := asInner(p.selectValues(express))
+	ret := make([]float64, values.Len())
+	values.EachV(func(v reflect.Value, i int) {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			ret[i] = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			ret[i] = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			ret[i] = v.Float()
+		default:
+			panic("unknown type " + v.Type().String())
+		}
+	})
+	return ret
+}
+
+// the k-th smallest value of a, a is partially reordered so that
+// a[:k] <= a[k] <= a[k+1:]
+func selectK(a []float64, k int) float64 {
+	lo, hi := 0, len(a)-1
+	for lo < hi {
+		// median of three as pivot
+		mid := lo + (hi-lo)/2
+		if a[mid] < a[lo] {
+			a[mid], a[lo] = a[lo], a[mid]
+		}
+		if a[hi] < a[lo] {
+			a[hi], a[lo] = a[lo], a[hi]
+		}
+		if a[hi] < a[mid] {
+			a[hi], a[mid] = a[mid], a[hi]
+		}
+		pivot := a[mid]
+		i, j := lo, hi
+		for i <= j {
+			for a[i] < pivot {
+				i++
+			}
+			for a[j] > pivot {
+				j--
+			}
+			if i <= j {
+				a[i], a[j] = a[j], a[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return a[k]
+		}
+	}
+	return a[k]
+}
+
+// the value at rank r of a (0 <= r <= len(a)-1), linearly interpolated between closest ranks
+// a[from:] is searched, the values before from are all smaller
+func rankOf(a []float64, from int, r float64) float64 {
+	k := int(r)
+	lower := selectK(a[from:], k-from)
+	if frac := r - float64(k); frac > 0 {
+		// a[k+1:] >= a[k] after selectK, so the next rank is the minimum of the rest
+		upper := a[k+1]
+		for _, v := range a[k+2:] {
+			if v < upper {
+				upper = v
+			}
+		}
+		return lower + (upper-lower)*frac
+	}
+	return lower
+}
+
+func (p *_array) Median(express interface{}) float64 {
+	return p.Percentile(express, 50)
+}
+
+func (p *_array) Percentile(express interface{}, percent float64) float64 {
+	if percent < 0 || percent > 100 || math.IsNaN(percent) {
+		panic(fmt.Sprintf("percent %v is out of range [0, 100]", percent))
+	}
+	values := p.floats(express)
+	if len(values) == 0 {
+		return float64(0)
+	}
+	return rankOf(values, 0, percent/100*float64(len(values)-1))
+}
+
+func (p *_array) Quantiles(express interface{}, n int) []float64 {
+	if n < 1 {
+		panic(fmt.Sprintf("n %d must be greater than 0", n))
+	}
+	values := p.floats(express)
+	ret := make([]float64, n-1)
+	if len(values) == 0 {
+		return ret
+	}
+	from := 0
+	for i := range ret {
+		r := float64(i+1) / float64(n) * float64(len(values)-1)
+		ret[i] = rankOf(values, from, r)
+		// the cut points are ascending, the next one searches after this rank only
+		from = int(r)
+	}
+	return ret
+}
+
+// count, mean and the sum of squared differences from the mean, by Welford's algorithm
+func (p *_array) moments(express interface{}) (n int, mean, m2 float64) {
+	for _, v := range p.floats(express) {
+		n++
+		d := v - mean
+		mean += d / float64(n)
+		m2 += d * (v - mean)
+	}
+	return
+}
+
+func (p *_array) Variance(express interface{}) float64 {
+	n, _, m2 := p.moments(express)
+	if n == 0 {
+		return float64(0)
+	}
+	return m2 / float64(n)
+}
+
+func (p *_array) SampleVariance(express interface{}) float64 {
+	n, _, m2 := p.moments(express)
+	if n < 2 {
+		return float64(0)
+	}
+	return m2 / float64(n-1)
+}
+
+func (p *_array) StdDev(express interface{}) float64 {
+	return math.Sqrt(p.Variance(express))
+}
+
+func (p *_array) SampleStdDev(express interface{}) float64 {
+	return math.Sqrt(p.SampleVariance(express))
+}
+
+func (p *_array) Mode(express interface{}) interface{} {
+	values := asInner(p.selectValues(express))
+	if values.Len() == 0 {
+		return nil
+	}
+	x := values.indexBy(identity, values.elementType)
+	m := 0
+	for i, g := range x.groups {
+		if len(g) > len(x.groups[m]) {
+			m = i
+		}
+	}
+	return x.keys[m]
+}
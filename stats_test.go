@@ -0,0 +1,81 @@
+package lambda
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestStats_Percentile(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{7, 1, 5, 3, 9, 2})
+	isTrue(t, arr.Median(nil) == 4)
+	isTrue(t, arr.Percentile(nil, 0) == 1)
+	isTrue(t, arr.Percentile(nil, 100) == 9)
+	isTrue(t, arr.Percentile(nil, 90) == 8)
+	isTrue(t, fmt.Sprint(arr.Quantiles(nil, 4)) == "[2.25 4 6.5]")
+	// the source is untouched
+	isTrue(t, fmt.Sprint(arr.Pointer()) == "[7 1 5 3 9 2]")
+
+	isTrue(t, LambdaArray([]int{}).Median(nil) == 0)
+	isTrue(t, len(LambdaArray([]int{}).Quantiles(nil, 4)) == 3)
+	us := makeUsers()
+	isTrue(t, LambdaArray(us).Median(func(u user) int { return u.age }) == 26)
+
+	defer func() {
+		isTrue(t, recover() != nil)
+	}()
+	arr.Percentile(nil, 101)
+}
+
+func TestStats_PercentileRandom(t *testing.T) {
+	defer report(t, time.Now())
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 200; n += 7 {
+		values := make([]float64, n)
+		for i := range values {
+			// few distinct values to exercise equal keys
+			values[i] = float64(r.Intn(n/3 + 1))
+		}
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		arr := LambdaArray(values)
+		for _, q := range arr.Quantiles(nil, 10) {
+			isTrue(t, q >= sorted[0] && q <= sorted[n-1])
+		}
+		for _, percent := range []float64{0, 10, 25, 50, 75, 99, 100} {
+			r := percent / 100 * float64(n-1)
+			k := int(r)
+			want := sorted[k]
+			if k+1 < n {
+				want += (sorted[k+1] - sorted[k]) * (r - float64(k))
+			}
+			isTrue(t, math.Abs(arr.Percentile(nil, percent)-want) < 1e-9)
+		}
+	}
+}
+
+func TestStats_Variance(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{2, 4, 4, 4, 5, 5, 7, 9})
+	isTrue(t, arr.Variance(nil) == 4)
+	isTrue(t, arr.StdDev(nil) == 2)
+	isTrue(t, math.Abs(arr.SampleVariance(nil)-32.0/7) < 1e-12)
+	isTrue(t, math.Abs(arr.SampleStdDev(nil)-math.Sqrt(32.0/7)) < 1e-12)
+	isTrue(t, LambdaArray([]float64{1}).SampleVariance(nil) == 0)
+	isTrue(t, LambdaArray([]float64{}).Variance(nil) == 0)
+	isTrue(t, LambdaArray([]float32{1.5, 2.5}).Variance(func(f float32) float32 { return f * 2 }) == 1)
+}
+
+func TestStats_Mode(t *testing.T) {
+	defer report(t, time.Now())
+	isTrue(t, LambdaArray([]int{2, 4, 4, 4, 5, 5, 7, 9}).Mode(nil) == 4)
+	// the first one wins on equal counts
+	isTrue(t, LambdaArray([]string{"b", "a", "a", "b"}).Mode(nil) == "b")
+	isTrue(t, LambdaArray([]int{}).Mode(nil) == nil)
+	us := makeUsers()
+	isTrue(t, LambdaArray(us).Mode(func(u user) int { return u.age / 10 }) == 2)
+}
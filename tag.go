@@ -0,0 +1,218 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// _tags are the fields of a struct tagged by `lambda:"..."`
+//
+//	lambda:"key"       the field is part of the equality of the struct, not comparable fields are deeply equal
+//	lambda:"sort"      the struct is ordered by the field ascending, by declaration order of the sort fields
+//	lambda:"sort,desc" the struct is ordered by the field descending
+//	lambda:"key,sort"  both
+//
+// the struct is ordered by the key fields when there is no sort field,
+// and equal by the sort fields when there is no key field
+type _tags struct {
+	// equality fields, the sort fields when there is no key field
+	keys [][]int
+	// ordering fields, the key fields when there is no sort field,
+	// nil when the key fields are not ordered
+	sorts []tagSort
+	// the equality fields are number Type, string, bool or hashable tagged structs
+	hashable bool
+}
+
+type tagSort struct {
+	index []int
+	desc  bool
+}
+
+var tagCache sync.Map
+
+// the tagged fields of t, nil when t is not a struct with lambda tags
+func tagsOf(t reflect.Type) *_tags {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if tags, ok := tagCache.Load(t); ok {
+		return tags.(*_tags)
+	}
+	tags := &_tags{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("lambda")
+		if !ok || tag == "-" {
+			continue
+		}
+		var key, sort, desc bool
+		for _, option := range strings.Split(tag, ",") {
+			switch strings.TrimSpace(option) {
+			case "key":
+				key = true
+			case "sort":
+				sort = true
+			case "desc":
+				desc = true
+			default:
+				panic(fmt.Sprintf("unknown lambda tag option %q of %s.%s", option, t.String(), f.Name))
+			}
+		}
+		if desc && !sort {
+			panic(fmt.Sprintf("lambda tag desc without sort of %s.%s", t.String(), f.Name))
+		}
+		if sort && !orderedField(f.Type) {
+			panic(fmt.Sprintf("sort field %s.%s of %s is not ordered", t.String(), f.Name, f.Type.String()))
+		}
+		if key {
+			tags.keys = append(tags.keys, f.Index)
+		}
+		if sort {
+			tags.sorts = append(tags.sorts, tagSort{f.Index, desc})
+		}
+	}
+	switch {
+	case len(tags.keys) == 0 && len(tags.sorts) == 0:
+		tags = nil
+	case len(tags.keys) == 0:
+		for _, s := range tags.sorts {
+			tags.keys = append(tags.keys, s.index)
+		}
+	case len(tags.sorts) == 0:
+		for _, index := range tags.keys {
+			if !orderedField(t.FieldByIndex(index).Type) {
+				// not ordered, the struct has equality only
+				tags.sorts = nil
+				break
+			}
+			tags.sorts = append(tags.sorts, tagSort{index: index})
+		}
+	}
+	if tags != nil {
+		tags.hashable = true
+		for _, index := range tags.keys {
+			ft := t.FieldByIndex(index).Type
+			nested := tagsOf(ft)
+			if !isNumber(ft) && !isString(ft) && !isBool(ft) && (nested == nil || !nested.hashable) {
+				tags.hashable = false
+			}
+		}
+	}
+	// the typed nil is cached too, so untagged structs are parsed once
+	actual, _ := tagCache.LoadOrStore(t, tags)
+	return actual.(*_tags)
+}
+
+// values of t are ordered by the total order or by their tags
+func orderedField(t reflect.Type) bool {
+	if ordered(t) || t.Implements(compareType) {
+		return true
+	}
+	tags := tagsOf(t)
+	return tags != nil && tags.sorts != nil
+}
+
+func (tags *_tags) compare(a, b reflect.Value) int {
+	a, b = addressable(a), addressable(b)
+	for _, s := range tags.sorts {
+		if c := compareField(a.FieldByIndex(s.index), b.FieldByIndex(s.index)); c != 0 {
+			if s.desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
+
+func (tags *_tags) equal(a, b reflect.Value) bool {
+	a, b = addressable(a), addressable(b)
+	for _, index := range tags.keys {
+		if !equalField(a.FieldByIndex(index), b.FieldByIndex(index)) {
+			return false
+		}
+	}
+	return true
+}
+
+// comparable value of the equality fields, it is the hash key of the struct when hashable
+func (tags *_tags) hash(v reflect.Value) interface{} {
+	keys := tags.keys
+	h := reflect.New(reflect.ArrayOf(len(keys), anyType)).Elem()
+	for i, index := range keys {
+		f := fieldStep(v.Type(), index)(v)
+		if ft := tagsOf(f.Type()); ft != nil {
+			h.Index(i).Set(reflect.ValueOf(ft.hash(f)))
+		} else {
+			h.Index(i).Set(f)
+		}
+	}
+	return h.Interface()
+}
+
+// a Compare or Equal implementation wins over the lambda tags,
+// a and b are addressable fields, they are made accessible when unexported
+func compareField(a, b reflect.Value) int {
+	if a.Type().Implements(compareType) {
+		return accessible(a).Interface().(Compare).CompareTo(accessible(b).Interface())
+	}
+	if tags := tagsOf(a.Type()); tags != nil {
+		return tags.compare(a, b)
+	}
+	return compareValues(a, b, NilFirst)
+}
+
+func equalField(a, b reflect.Value) bool {
+	switch t := a.Type(); {
+	case t.Implements(equalType):
+		return accessible(a).Interface().(Equal).Equals(accessible(b).Interface())
+	case t.Implements(compareType):
+		return accessible(a).Interface().(Compare).CompareTo(accessible(b).Interface()) == 0
+	case tagsOf(t) != nil:
+		return tagsOf(t).equal(a, b)
+	case ordered(t):
+		return compareValues(a, b, NilFirst) == 0
+	}
+	return reflect.DeepEqual(accessible(a).Interface(), accessible(b).Interface())
+}
+
+// TaggedCompare is the Compare and Equal of a struct by its lambda tags
+type TaggedCompare struct {
+	v    reflect.Value
+	tags *_tags
+}
+
+// TaggedComparator returns the Compare and Equal of ele by the lambda tags of its struct type,
+// error when the type has no lambda tag.
+// the struct is ordered by the sort fields, or by the key fields when there is no sort field and they are ordered
+func TaggedComparator(ele interface{}) (*TaggedCompare, error) {
+	v := reflect.ValueOf(ele)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%v has no lambda tag", ele)
+	}
+	tags := tagsOf(v.Type())
+	if tags == nil {
+		return nil, fmt.Errorf("%s has no lambda tag", v.Type().String())
+	}
+	return &TaggedCompare{v, tags}, nil
+}
+
+// panics when the struct is not ordered, see TaggedComparator
+func (p *TaggedCompare) CompareTo(a interface{}) int {
+	if p.tags.sorts == nil {
+		panic(fmt.Sprintf("%s has no ordered key or sort field", p.v.Type().String()))
+	}
+	av := reflect.ValueOf(a)
+	if !av.IsValid() || av.Type() != p.v.Type() {
+		panic(fmt.Sprintf("%s is not %v", p.v.Type().String(), reflect.TypeOf(a)))
+	}
+	return p.tags.compare(p.v, av)
+}
+
+func (p *TaggedCompare) Equals(obj interface{}) bool {
+	ov := reflect.ValueOf(obj)
+	return ov.IsValid() && ov.Type() == p.v.Type() && p.tags.equal(p.v, ov)
+}
@@ -0,0 +1,112 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type product struct {
+	SKU   string  `lambda:"key"`
+	Name  string  `lambda:"sort"`
+	Price float64 `lambda:"sort,desc"`
+	stock int
+}
+
+type line struct {
+	order   int `lambda:"key,sort"`
+	product product
+	qty     int `lambda:"sort,desc"`
+}
+
+type release struct {
+	version []int `lambda:"key"`
+	date    time.Time
+}
+
+type holder struct {
+	owner account `lambda:"key,sort"`
+}
+
+func makeProducts() []product {
+	return []product{
+		{"P3", "pen", 1.5, 10},
+		{"P1", "book", 12, 3},
+		{"P2", "pen", 2, 0},
+		{"P1", "book", 12, 7},
+	}
+}
+
+func skus(arr Array) string {
+	return fmt.Sprint(arr.Map(Field("SKU")).Pointer())
+}
+
+func TestTag_Order(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeProducts())
+	// by Name, then by Price descending
+	isTrue(t, skus(LambdaArray(makeProducts()).Sort(nil)) == "[P1 P1 P2 P3]")
+	isTrue(t, skus(arr.OrderBy(func(p product) product { return p })) == "[P1 P1 P2 P3]")
+	isTrue(t, arr.Max(nil).(product).SKU == "P3")
+	isTrue(t, arr.Min(nil).(product).SKU == "P1")
+	isTrue(t, fmt.Sprint(LambdaArray([]int{3, 1, 2}).Sort(nil).Pointer()) == "[1 2 3]")
+	isTrue(t, fmt.Sprint(LambdaArray([]string{"b", "c", "a"}).SortMT(nil).Pointer()) == "[a b c]")
+	isTrue(t, skus(LambdaArray(makeProducts()).SortMT(nil)) == "[P1 P1 P2 P3]")
+
+	lines := LambdaArray([]line{{2, product{}, 1}, {1, product{}, 1}, {1, product{}, 5}})
+	isTrue(t, fmt.Sprint(lines.Map(func(l line) int { return l.qty }).Pointer()) == "[1 1 5]")
+	sorted := lines.Sort(nil).Map(func(l line) string { return fmt.Sprint(l.order, ":", l.qty) }).Pointer()
+	isTrue(t, fmt.Sprint(sorted) == "[1:5 1:1 2:1]")
+
+	tor, err := BasicComparator(product{Name: "a"})
+	isTrue(t, err == nil && tor.CompareTo(product{Name: "b"}) < 0)
+	_, err = TaggedComparator(user{})
+	isTrue(t, err != nil)
+}
+
+func TestTag_Equality(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray(makeProducts())
+	// equal by SKU only
+	isTrue(t, arr.Contains(product{SKU: "P2"}))
+	isFalse(t, arr.Contains(product{SKU: "P4", Name: "pen", Price: 1.5}))
+	isTrue(t, skus(arr.Distinct()) == "[P3 P1 P2]")
+	isTrue(t, arr.Distinct().Pointer().([]product)[1].stock == 3)
+	isTrue(t, skus(arr.Except(LambdaArray([]product{{SKU: "P1"}}))) == "[P3 P2]")
+	isTrue(t, skus(arr.Intersect(LambdaArray([]product{{SKU: "P2", stock: 99}}))) == "[P2]")
+	isTrue(t, arr.GroupBy(func(p product) product { return p }).Count(nil) == 3)
+
+	// not hashable keys are matched by scanning
+	v1 := release{[]int{1, 0}, time.Now()}
+	v2 := release{[]int{1, 1}, time.Now()}
+	releases := LambdaArray([]release{v1, v2, {[]int{1, 0}, time.Now()}})
+	isTrue(t, releases.Distinct().Count(nil) == 2)
+	isTrue(t, releases.Contains(release{version: []int{1, 1}}))
+	tc, _ := TaggedComparator(v1)
+	isTrue(t, tc.Equals(release{version: []int{1, 0}}))
+	_, err := BasicComparator(v1)
+	isTrue(t, err != nil)
+}
+
+func TestTag_UnexportedCompare(t *testing.T) {
+	defer report(t, time.Now())
+	// the unexported owner is compared by account.CompareTo, the ages only
+	hs := LambdaArray([]holder{{account{"c", 30}}, {account{"a", 10}}, {account{"b", 20}}, {account{"d", 10}}})
+	ages := hs.Sort(nil).Map(func(h holder) int { return h.owner.age }).Pointer()
+	isTrue(t, fmt.Sprint(ages) == "[10 10 20 30]")
+	isTrue(t, hs.Max(nil).(holder).owner.name == "c")
+	isTrue(t, hs.Min(nil).(holder).owner.age == 10)
+	isTrue(t, hs.Distinct().Count(nil) == 3)
+	isTrue(t, hs.Contains(holder{account{"x", 20}}))
+}
+
+func TestTag_Invalid(t *testing.T) {
+	defer report(t, time.Now())
+	type bad struct {
+		v int `lambda:"unique"`
+	}
+	defer func() {
+		isTrue(t, recover() != nil)
+	}()
+	TaggedComparator(bad{})
+}
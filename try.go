@@ -0,0 +1,718 @@
+package lambda
+
+import (
+	"io"
+	"math/big"
+)
+
+// TryArray is the non-panicking form of Array
+// the first error is carried through the chain and returned by the terminal operator,
+// the operators after a failed one are not executed.
+// errors are *OpError, errors.As(err, &*ExpressError) when a lambda express does not match
+type TryArray interface {
+
+	// see Array.Filter
+	Filter(express interface{}) TryArray
+
+	// see Array.Sort
+	Sort(express interface{}) TryArray
+
+	// see Array.SortMT
+	SortMT(express interface{}) TryArray
+
+	// see Array.OrderBy
+	OrderBy(express interface{}) TryOrderedArray
+
+	// see Array.OrderByDescending
+	OrderByDescending(express interface{}) TryOrderedArray
+
+	// see Array.Map
+	Map(express interface{}) TryArray
+
+	// see Array.Append
+	Append(elements ...interface{}) TryArray
+
+	// see Array.Take
+	Take(skip, count int) TryArray
+
+	// see Array.Chunk
+	Chunk(size int) TryArray
+
+	// see Array.Window
+	Window(size, step int) TryArray
+
+	// see Array.WindowMap
+	WindowMap(size, step int, express interface{}) TryArray
+
+	// see Array.WindowSum
+	WindowSum(size, step int, express interface{}) TryArray
+
+	// see Array.WindowAverage
+	WindowAverage(size, step int, express interface{}) TryArray
+
+	// see Array.WindowMax
+	WindowMax(size, step int, express interface{}) TryArray
+
+	// see Array.WindowMin
+	WindowMin(size, step int, express interface{}) TryArray
+
+	// see Array.Scan
+	Scan(seed, express interface{}) TryArray
+
+	// see Array.RunningSum
+	RunningSum(express interface{}) TryArray
+
+	// see Array.RunningMax
+	RunningMax(express interface{}) TryArray
+
+	// see Array.RunningMin
+	RunningMin(express interface{}) TryArray
+
+	// see Array.Zip
+	Zip(other Array, express interface{}) TryArray
+
+	// see Array.ZipLongest
+	ZipLongest(other Array, express, defaultA, defaultB interface{}) TryArray
+
+	// see Array.Distinct
+	Distinct() TryArray
+
+	// see Array.DistinctBy
+	DistinctBy(express interface{}) TryArray
+
+	// see Array.Union
+	Union(other Array) TryArray
+
+	// see Array.Intersect
+	Intersect(other Array) TryArray
+
+	// see Array.Except
+	Except(other Array) TryArray
+
+	// see Array.GroupBy
+	GroupBy(express interface{}) TryArray
+
+	// see Array.InnerJoin
+	InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) TryArray
+
+	// see Array.LeftJoin
+	LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) TryArray
+
+	// see Array.GroupJoin
+	GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) TryArray
+
+	// see Array.ToLookup
+	ToLookup(express interface{}) (Lookup, error)
+
+	// see Array.IsSlice
+	IsSlice() (bool, error)
+
+	// see Array.Join
+	Join(options JoinOptions) (string, error)
+
+	// see Array.Max
+	Max(express interface{}) (interface{}, error)
+
+	// see Array.Min
+	Min(express interface{}) (interface{}, error)
+
+	// see Array.Any
+	Any(express interface{}) (bool, error)
+
+	// see Array.All
+	All(express interface{}) (bool, error)
+
+	// see Array.Count
+	Count(express interface{}) (int, error)
+
+	// see Array.First
+	First(express interface{}) (interface{}, error)
+
+	// see Array.Last
+	Last(express interface{}) (interface{}, error)
+
+	// see Array.Index
+	Index(i int) (interface{}, error)
+
+	// see Array.Sum
+	Sum(express interface{}) (interface{}, error)
+
+	// see Array.Average
+	Average(express interface{}) (float64, error)
+
+	// see Array.SumWith, the overflow of Checked or Widen is returned as error
+	SumWith(express interface{}, options SumOptions) (interface{}, error)
+
+	// see Array.AverageWith
+	AverageWith(express interface{}, options SumOptions) (float64, error)
+
+	// see Array.AverageValue
+	AverageValue(express interface{}) (interface{}, error)
+
+	// see Array.SumDecimal
+	SumDecimal(express interface{}) (*big.Rat, error)
+
+	// see Array.AverageDecimal
+	AverageDecimal(express interface{}) (*big.Rat, error)
+
+	// see Array.Median
+	Median(express interface{}) (float64, error)
+
+	// see Array.Percentile
+	Percentile(express interface{}, percent float64) (float64, error)
+
+	// see Array.Quantiles
+	Quantiles(express interface{}, n int) ([]float64, error)
+
+	// see Array.Variance
+	Variance(express interface{}) (float64, error)
+
+	// see Array.SampleVariance
+	SampleVariance(express interface{}) (float64, error)
+
+	// see Array.StdDev
+	StdDev(express interface{}) (float64, error)
+
+	// see Array.SampleStdDev
+	SampleStdDev(express interface{}) (float64, error)
+
+	// see Array.Mode
+	Mode(express interface{}) (interface{}, error)
+
+	// see Array.Aggregate
+	Aggregate(seed, express, resultSelector interface{}) (interface{}, error)
+
+	// see Array.Reduce, the error of the empty array is returned as it is
+	Reduce(express interface{}) (interface{}, error)
+
+	// see Array.Contains
+	Contains(express interface{}) (bool, error)
+
+	// see Array.WriteCSV, the write error is returned as it is
+	WriteCSV(w io.Writer) error
+
+	// see Array.WriteJSONLines, the write error is returned as it is
+	WriteJSONLines(w io.Writer) error
+
+	// see Array.Pointer
+	Pointer() (interface{}, error)
+
+	// the Array of the chain, AsParallel, WithContext and AsEnumerable are called on it
+	Array() (Array, error)
+
+	// the first error of the chain
+	Err() error
+}
+
+// TryOrderedArray is the non-panicking form of OrderedArray
+type TryOrderedArray interface {
+	TryArray
+
+	// see OrderedArray.ThenBy
+	ThenBy(express interface{}) TryOrderedArray
+
+	// see OrderedArray.ThenByDescending
+	ThenByDescending(express interface{}) TryOrderedArray
+}
+
+type _try struct {
+	// result of the chain, nil when err is not nil
+	arr Array
+	// first error of the chain
+	err error
+}
+
+// make TryArray from source(TIn[] type)
+// source support array or slice type, the error is returned by the terminal operator
+func TryLambdaArray(source interface{}) TryArray {
+	p := &_try{}
+	p.err = catch("LambdaArray", []param{{"source", source}}, func() {
+		p.arr = LambdaArray(source)
+	})
+	return p
+}
+
+// make TryArray from Array
+func TryOf(arr Array) TryArray {
+	return &_try{arr: arr}
+}
+
+// run the chain operator
+func (p *_try) then(op string, params []param, fn func(arr Array) Array) TryArray {
+	if p.err != nil {
+		return p
+	}
+	ret := &_try{}
+	ret.err = catch(op, params, func() {
+		ret.arr = fn(p.arr)
+	})
+	return ret
+}
+
+// run the terminal operator
+func (p *_try) end(op string, params []param, fn func(arr Array)) error {
+	if p.err != nil {
+		return p.err
+	}
+	return catch(op, params, func() {
+		fn(p.arr)
+	})
+}
+
+func (p *_try) Filter(express interface{}) TryArray {
+	return p.then("Filter", []param{{"express", express}}, func(arr Array) Array {
+		return arr.Filter(express)
+	})
+}
+
+func (p *_try) Sort(express interface{}) TryArray {
+	return p.then("Sort", []param{{"express", express}}, func(arr Array) Array {
+		return arr.Sort(express)
+	})
+}
+
+func (p *_try) SortMT(express interface{}) TryArray {
+	return p.then("SortMT", []param{{"express", express}}, func(arr Array) Array {
+		return arr.SortMT(express)
+	})
+}
+
+func (p *_try) Map(express interface{}) TryArray {
+	return p.then("Map", []param{{"express", express}}, func(arr Array) Array {
+		return arr.Map(express)
+	})
+}
+
+type _tryOrdered struct {
+	*_try
+}
+
+// run the sorting operator, the Array of the chain is an OrderedArray when there is no error
+func (p *_try) ordered(op string, express interface{}, fn func(arr Array) Array) TryOrderedArray {
+	return &_tryOrdered{p.then(op, []param{{"express", express}}, fn).(*_try)}
+}
+
+func (p *_try) OrderBy(express interface{}) TryOrderedArray {
+	return p.ordered("OrderBy", express, func(arr Array) Array {
+		return arr.OrderBy(express)
+	})
+}
+
+func (p *_try) OrderByDescending(express interface{}) TryOrderedArray {
+	return p.ordered("OrderByDescending", express, func(arr Array) Array {
+		return arr.OrderByDescending(express)
+	})
+}
+
+func (p *_tryOrdered) ThenBy(express interface{}) TryOrderedArray {
+	return p.ordered("ThenBy", express, func(arr Array) Array {
+		return arr.(OrderedArray).ThenBy(express)
+	})
+}
+
+func (p *_tryOrdered) ThenByDescending(express interface{}) TryOrderedArray {
+	return p.ordered("ThenByDescending", express, func(arr Array) Array {
+		return arr.(OrderedArray).ThenByDescending(express)
+	})
+}
+
+func (p *_try) Append(elements ...interface{}) TryArray {
+	return p.then("Append", []param{{"elements", elements}}, func(arr Array) Array {
+		return arr.Append(elements...)
+	})
+}
+
+func (p *_try) Take(skip, count int) TryArray {
+	return p.then("Take", nil, func(arr Array) Array {
+		return arr.Take(skip, count)
+	})
+}
+
+func (p *_try) Chunk(size int) TryArray {
+	return p.then("Chunk", nil, func(arr Array) Array {
+		return arr.Chunk(size)
+	})
+}
+
+func (p *_try) Window(size, step int) TryArray {
+	return p.then("Window", nil, func(arr Array) Array {
+		return arr.Window(size, step)
+	})
+}
+
+func (p *_try) WindowMap(size, step int, express interface{}) TryArray {
+	params := []param{{"size", size}, {"step", step}, {"express", express}}
+	return p.then("WindowMap", params, func(arr Array) Array {
+		return arr.WindowMap(size, step, express)
+	})
+}
+
+func (p *_try) WindowSum(size, step int, express interface{}) TryArray {
+	params := []param{{"size", size}, {"step", step}, {"express", express}}
+	return p.then("WindowSum", params, func(arr Array) Array {
+		return arr.WindowSum(size, step, express)
+	})
+}
+
+func (p *_try) WindowAverage(size, step int, express interface{}) TryArray {
+	params := []param{{"size", size}, {"step", step}, {"express", express}}
+	return p.then("WindowAverage", params, func(arr Array) Array {
+		return arr.WindowAverage(size, step, express)
+	})
+}
+
+func (p *_try) WindowMax(size, step int, express interface{}) TryArray {
+	params := []param{{"size", size}, {"step", step}, {"express", express}}
+	return p.then("WindowMax", params, func(arr Array) Array {
+		return arr.WindowMax(size, step, express)
+	})
+}
+
+func (p *_try) WindowMin(size, step int, express interface{}) TryArray {
+	params := []param{{"size", size}, {"step", step}, {"express", express}}
+	return p.then("WindowMin", params, func(arr Array) Array {
+		return arr.WindowMin(size, step, express)
+	})
+}
+
+func (p *_try) Scan(seed, express interface{}) TryArray {
+	return p.then("Scan", []param{{"seed", seed}, {"express", express}}, func(arr Array) Array {
+		return arr.Scan(seed, express)
+	})
+}
+
+func (p *_try) RunningSum(express interface{}) TryArray {
+	return p.then("RunningSum", []param{{"express", express}}, func(arr Array) Array {
+		return arr.RunningSum(express)
+	})
+}
+
+func (p *_try) RunningMax(express interface{}) TryArray {
+	return p.then("RunningMax", []param{{"express", express}}, func(arr Array) Array {
+		return arr.RunningMax(express)
+	})
+}
+
+func (p *_try) RunningMin(express interface{}) TryArray {
+	return p.then("RunningMin", []param{{"express", express}}, func(arr Array) Array {
+		return arr.RunningMin(express)
+	})
+}
+
+func (p *_try) Zip(other Array, express interface{}) TryArray {
+	return p.then("Zip", []param{{"other", other}, {"express", express}}, func(arr Array) Array {
+		return arr.Zip(other, express)
+	})
+}
+
+func (p *_try) ZipLongest(other Array, express, defaultA, defaultB interface{}) TryArray {
+	params := []param{{"other", other}, {"express", express}, {"defaultA", defaultA}, {"defaultB", defaultB}}
+	return p.then("ZipLongest", params, func(arr Array) Array {
+		return arr.ZipLongest(other, express, defaultA, defaultB)
+	})
+}
+
+func (p *_try) Distinct() TryArray {
+	return p.then("Distinct", nil, func(arr Array) Array {
+		return arr.Distinct()
+	})
+}
+
+func (p *_try) DistinctBy(express interface{}) TryArray {
+	return p.then("DistinctBy", []param{{"express", express}}, func(arr Array) Array {
+		return arr.DistinctBy(express)
+	})
+}
+
+func (p *_try) Union(other Array) TryArray {
+	return p.then("Union", []param{{"other", other}}, func(arr Array) Array {
+		return arr.Union(other)
+	})
+}
+
+func (p *_try) Intersect(other Array) TryArray {
+	return p.then("Intersect", []param{{"other", other}}, func(arr Array) Array {
+		return arr.Intersect(other)
+	})
+}
+
+func (p *_try) Except(other Array) TryArray {
+	return p.then("Except", []param{{"other", other}}, func(arr Array) Array {
+		return arr.Except(other)
+	})
+}
+
+func (p *_try) GroupBy(express interface{}) TryArray {
+	return p.then("GroupBy", []param{{"express", express}}, func(arr Array) Array {
+		return arr.GroupBy(express)
+	})
+}
+
+func joinParams(other Array, outerKey, innerKey, resultSelector interface{}) []param {
+	return []param{
+		{"other", other},
+		{"outerKey", outerKey},
+		{"innerKey", innerKey},
+		{"resultSelector", resultSelector},
+	}
+}
+
+func (p *_try) InnerJoin(other Array, outerKey, innerKey, resultSelector interface{}) TryArray {
+	return p.then("InnerJoin", joinParams(other, outerKey, innerKey, resultSelector), func(arr Array) Array {
+		return arr.InnerJoin(other, outerKey, innerKey, resultSelector)
+	})
+}
+
+func (p *_try) LeftJoin(other Array, outerKey, innerKey, resultSelector, defaultInner interface{}) TryArray {
+	params := append(joinParams(other, outerKey, innerKey, resultSelector), param{"defaultInner", defaultInner})
+	return p.then("LeftJoin", params, func(arr Array) Array {
+		return arr.LeftJoin(other, outerKey, innerKey, resultSelector, defaultInner)
+	})
+}
+
+func (p *_try) GroupJoin(other Array, outerKey, innerKey, resultSelector interface{}) TryArray {
+	return p.then("GroupJoin", joinParams(other, outerKey, innerKey, resultSelector), func(arr Array) Array {
+		return arr.GroupJoin(other, outerKey, innerKey, resultSelector)
+	})
+}
+
+func (p *_try) ToLookup(express interface{}) (ret Lookup, err error) {
+	err = p.end("ToLookup", []param{{"express", express}}, func(arr Array) {
+		ret = arr.ToLookup(express)
+	})
+	return
+}
+
+func (p *_try) IsSlice() (ret bool, err error) {
+	err = p.end("IsSlice", nil, func(arr Array) {
+		ret = arr.IsSlice()
+	})
+	return
+}
+
+func (p *_try) Join(options JoinOptions) (ret string, err error) {
+	err = p.end("Join", []param{{"options.express", options.express}}, func(arr Array) {
+		ret = arr.Join(options)
+	})
+	return
+}
+
+func (p *_try) Max(express interface{}) (ret interface{}, err error) {
+	err = p.end("Max", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Max(express)
+	})
+	return
+}
+
+func (p *_try) Min(express interface{}) (ret interface{}, err error) {
+	err = p.end("Min", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Min(express)
+	})
+	return
+}
+
+func (p *_try) Any(express interface{}) (ret bool, err error) {
+	err = p.end("Any", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Any(express)
+	})
+	return
+}
+
+func (p *_try) All(express interface{}) (ret bool, err error) {
+	err = p.end("All", []param{{"express", express}}, func(arr Array) {
+		ret = arr.All(express)
+	})
+	return
+}
+
+func (p *_try) Count(express interface{}) (ret int, err error) {
+	err = p.end("Count", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Count(express)
+	})
+	return
+}
+
+func (p *_try) First(express interface{}) (ret interface{}, err error) {
+	var found error
+	if err = p.end("First", []param{{"express", express}}, func(arr Array) {
+		ret, found = arr.First(express)
+	}); err != nil {
+		return nil, err
+	}
+	return ret, found
+}
+
+func (p *_try) Last(express interface{}) (ret interface{}, err error) {
+	var found error
+	if err = p.end("Last", []param{{"express", express}}, func(arr Array) {
+		ret, found = arr.Last(express)
+	}); err != nil {
+		return nil, err
+	}
+	return ret, found
+}
+
+func (p *_try) Index(i int) (ret interface{}, err error) {
+	var found error
+	if err = p.end("Index", nil, func(arr Array) {
+		ret, found = arr.Index(i)
+	}); err != nil {
+		return nil, err
+	}
+	return ret, found
+}
+
+func (p *_try) Sum(express interface{}) (ret interface{}, err error) {
+	err = p.end("Sum", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Sum(express)
+	})
+	return
+}
+
+func (p *_try) Average(express interface{}) (ret float64, err error) {
+	err = p.end("Average", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Average(express)
+	})
+	return
+}
+
+func (p *_try) SumWith(express interface{}, options SumOptions) (ret interface{}, err error) {
+	err = p.end("SumWith", []param{{"express", express}}, func(arr Array) {
+		ret = arr.SumWith(express, options)
+	})
+	return
+}
+
+func (p *_try) AverageWith(express interface{}, options SumOptions) (ret float64, err error) {
+	err = p.end("AverageWith", []param{{"express", express}}, func(arr Array) {
+		ret = arr.AverageWith(express, options)
+	})
+	return
+}
+
+func (p *_try) AverageValue(express interface{}) (ret interface{}, err error) {
+	err = p.end("AverageValue", []param{{"express", express}}, func(arr Array) {
+		ret = arr.AverageValue(express)
+	})
+	return
+}
+
+func (p *_try) SumDecimal(express interface{}) (ret *big.Rat, err error) {
+	err = p.end("SumDecimal", []param{{"express", express}}, func(arr Array) {
+		ret = arr.SumDecimal(express)
+	})
+	return
+}
+
+func (p *_try) AverageDecimal(express interface{}) (ret *big.Rat, err error) {
+	err = p.end("AverageDecimal", []param{{"express", express}}, func(arr Array) {
+		ret = arr.AverageDecimal(express)
+	})
+	return
+}
+
+// run the statistic operator returning float64
+func (p *_try) stat(op string, express interface{}, fn func(arr Array) float64) (ret float64, err error) {
+	err = p.end(op, []param{{"express", express}}, func(arr Array) {
+		ret = fn(arr)
+	})
+	return
+}
+
+func (p *_try) Median(express interface{}) (float64, error) {
+	return p.stat("Median", express, func(arr Array) float64 { return arr.Median(express) })
+}
+
+func (p *_try) Percentile(express interface{}, percent float64) (float64, error) {
+	return p.stat("Percentile", express, func(arr Array) float64 { return arr.Percentile(express, percent) })
+}
+
+func (p *_try) Quantiles(express interface{}, n int) (ret []float64, err error) {
+	err = p.end("Quantiles", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Quantiles(express, n)
+	})
+	return
+}
+
+func (p *_try) Variance(express interface{}) (float64, error) {
+	return p.stat("Variance", express, func(arr Array) float64 { return arr.Variance(express) })
+}
+
+func (p *_try) SampleVariance(express interface{}) (float64, error) {
+	return p.stat("SampleVariance", express, func(arr Array) float64 { return arr.SampleVariance(express) })
+}
+
+func (p *_try) StdDev(express interface{}) (float64, error) {
+	return p.stat("StdDev", express, func(arr Array) float64 { return arr.StdDev(express) })
+}
+
+func (p *_try) SampleStdDev(express interface{}) (float64, error) {
+	return p.stat("SampleStdDev", express, func(arr Array) float64 { return arr.SampleStdDev(express) })
+}
+
+func (p *_try) Mode(express interface{}) (ret interface{}, err error) {
+	err = p.end("Mode", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Mode(express)
+	})
+	return
+}
+
+func (p *_try) Aggregate(seed, express, resultSelector interface{}) (ret interface{}, err error) {
+	params := []param{{"seed", seed}, {"express", express}, {"resultSelector", resultSelector}}
+	err = p.end("Aggregate", params, func(arr Array) {
+		ret = arr.Aggregate(seed, express, resultSelector)
+	})
+	return
+}
+
+func (p *_try) Reduce(express interface{}) (ret interface{}, err error) {
+	var empty error
+	if err = p.end("Reduce", []param{{"express", express}}, func(arr Array) {
+		ret, empty = arr.Reduce(express)
+	}); err != nil {
+		return nil, err
+	}
+	return ret, empty
+}
+
+func (p *_try) Contains(express interface{}) (ret bool, err error) {
+	err = p.end("Contains", []param{{"express", express}}, func(arr Array) {
+		ret = arr.Contains(express)
+	})
+	return
+}
+
+func (p *_try) WriteCSV(w io.Writer) error {
+	var written error
+	if err := p.end("WriteCSV", nil, func(arr Array) {
+		written = arr.WriteCSV(w)
+	}); err != nil {
+		return err
+	}
+	return written
+}
+
+func (p *_try) WriteJSONLines(w io.Writer) error {
+	var written error
+	if err := p.end("WriteJSONLines", nil, func(arr Array) {
+		written = arr.WriteJSONLines(w)
+	}); err != nil {
+		return err
+	}
+	return written
+}
+
+func (p *_try) Pointer() (ret interface{}, err error) {
+	err = p.end("Pointer", nil, func(arr Array) {
+		ret = arr.Pointer()
+	})
+	return
+}
+
+func (p *_try) Array() (Array, error) {
+	return p.arr, p.err
+}
+
+func (p *_try) Err() error {
+	return p.err
+}
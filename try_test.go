@@ -0,0 +1,157 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTryLambdaArray(t *testing.T) {
+	defer report(t, time.Now())
+	_, err := TryLambdaArray(1).Filter(func(e int) bool { return e > 0 }).Count(nil)
+	var oe *OpError
+	isTrue(t, errors.As(err, &oe) && oe.Op == "LambdaArray" && oe.Arg == "")
+
+	ret, err := TryLambdaArray([]int{1, 2, 3, 4, 5}).
+		Filter(func(e int) bool { return e > 2 }).
+		Map(func(e int) string { return fmt.Sprint(e) }).
+		Pointer()
+	isTrue(t, err == nil && fmt.Sprint(ret) == "[3 4 5]")
+}
+
+func TestTryArray_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	mapped := false
+	_, err := TryLambdaArray([]user{{"Abraham", 20}}).
+		Filter(func(u user) int { return u.age }).
+		Map(func(u user) string {
+			mapped = true
+			return u.name
+		}).
+		First(nil)
+	isFalse(t, mapped)
+	fmt.Println(err)
+
+	var oe *OpError
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Filter" && oe.Arg == "express")
+	var ee *ExpressError
+	isTrue(t, errors.As(err, &ee) && ee.Want == "func(lambda.user) bool")
+
+	_, err = TryLambdaArray([]user{{"Abraham", 20}}).Filter(nil).Count(nil)
+	isTrue(t, errors.As(err, &ee) && ee.Express == nil)
+}
+
+func TestTryArray_InnerJoin(t *testing.T) {
+	defer report(t, time.Now())
+	us := []user{{"Edith", 25}}
+	_, err := TryLambdaArray(us).InnerJoin(LambdaArray(makeOrders()),
+		func(u user) string { return u.name },
+		func(o order, i int) string { return o.owner },
+		func(u user, o order) int { return o.total }).Pointer()
+	var oe *OpError
+	isTrue(t, errors.As(err, &oe) && oe.Op == "InnerJoin" && oe.Arg == "innerKey")
+
+	// both keys are func(user) string, the error is of innerKey by its position
+	_, err = TryLambdaArray(us).InnerJoin(LambdaArray([]string{"Edith"}),
+		func(u user) string { return u.name },
+		func(u user) string { return u.name },
+		func(u user, name string) string { return name }).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Arg == "innerKey")
+	_, err = TryLambdaArray(us).GroupJoin(LambdaArray(us),
+		Field("name"),
+		Field("name"),
+		func(u user, o user) int { return o.age }).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Arg == "resultSelector")
+	_, err = TryLambdaArray(us).Filter(Field("age")).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Arg == "express")
+}
+
+func TestTryArray_Sum(t *testing.T) {
+	defer report(t, time.Now())
+	_, err := TryLambdaArray([]string{"a"}).Sum(nil)
+	isTrue(t, err != nil)
+	_, err = TryLambdaArray([]int{1}).Join(JoinOptions{})
+	isTrue(t, err != nil)
+	avg, err := TryOf(LambdaArray([]int{1, 2})).Average(nil)
+	isTrue(t, err == nil && avg == 1.5)
+
+	_, err = TryLambdaArray([]int{1}).First(func(e int) bool { return e > 1 })
+	var oe *OpError
+	isTrue(t, err != nil && !errors.As(err, &oe))
+}
+
+func TestTryArray_Operators(t *testing.T) {
+	defer report(t, time.Now())
+	var oe *OpError
+	us := TryLambdaArray([]user{{"Edith", 25}, {"Charles", 40}, {"Abel", 25}})
+	names, err := us.OrderByDescending(func(u user) int { return u.age }).ThenBy(Field("name")).Map(Field("name")).Pointer()
+	isTrue(t, err == nil && fmt.Sprint(names) == "[Charles Abel Edith]")
+	_, err = us.OrderBy(func(u user) int { return u.age }).ThenBy(func(u user, i int) string { return u.name }).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Op == "ThenBy" && oe.Arg == "express")
+
+	ints := TryLambdaArray([]int{1, 2, 3, 4})
+	sums, err := ints.Scan(0, func(acc, e int) int { return acc + e }).Pointer()
+	isTrue(t, err == nil && fmt.Sprint(sums) == "[1 3 6 10]")
+	_, err = ints.Scan(0, func(acc string, e int) string { return acc }).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Scan" && oe.Arg == "express")
+	_, err = ints.Aggregate(0, func(acc, e int) int { return acc + e }, func(acc string) string { return acc })
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Aggregate" && oe.Arg == "resultSelector")
+	_, err = ints.Zip(LambdaArray([]string{"a"}), func(a int, b int) int { return a }).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Zip" && oe.Arg == "express")
+	windows, err := ints.WindowMap(2, 1, func(w Array) int { return w.Sum(nil).(int) }).Pointer()
+	isTrue(t, err == nil && fmt.Sprint(windows) == "[3 5 7]")
+	windows, err = ints.WindowSum(2, 1, nil).Pointer()
+	isTrue(t, err == nil && fmt.Sprint(windows) == "[3 5 7]")
+	_, err = ints.WindowMax(0, 1, nil).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Op == "WindowMax")
+	_, err = ints.Chunk(0).Pointer()
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Chunk")
+	_, err = ints.Percentile(nil, 101)
+	isTrue(t, errors.As(err, &oe) && oe.Op == "Percentile" && oe.Arg == "")
+	median, err := ints.Median(nil)
+	isTrue(t, err == nil && median == 2.5)
+	_, err = TryLambdaArray([]string{"a"}).StdDev(nil)
+	isTrue(t, errors.As(err, &oe) && oe.Op == "StdDev")
+	_, err = TryLambdaArray([]int{}).Reduce(func(a, b int) int { return a + b })
+	isTrue(t, err != nil && !errors.As(err, &oe))
+	_, err = ints.ToLookup(func(e int) []int { return nil })
+	isTrue(t, errors.As(err, &oe) && oe.Op == "ToLookup")
+	err = ints.WriteCSV(io.Discard)
+	isTrue(t, errors.As(err, &oe) && oe.Op == "WriteCSV")
+}
+
+func TestTryArray_Panic(t *testing.T) {
+	defer report(t, time.Now())
+	ints := TryLambdaArray([]int{1, 0, 2})
+	// the sentinel errors of the library are returned
+	_, err := ints.Map("x => 10 / x").Pointer()
+	isTrue(t, errors.Is(err, ErrDivideByZero))
+	_, err = TryLambdaArray([]int8{100, 100}).SumWith(nil, SumOptions{Overflow: Checked})
+	isTrue(t, errors.Is(err, ErrOverflow))
+
+	// the bugs of an express are raised again with their stack
+	raised := func(fn func()) (r interface{}) {
+		defer func() { r = recover() }()
+		fn()
+		return nil
+	}
+	r := raised(func() {
+		var m map[int]int
+		_, _ = ints.Map(func(e int) int { m[e] = e; return e }).Pointer()
+	})
+	_, ok := r.(runtime.Error)
+	isTrue(t, ok)
+	r = raised(func() {
+		_, _ = ints.Filter(func(e int) bool { return []int{}[e] > 0 }).Pointer()
+	})
+	_, ok = r.(runtime.Error)
+	isTrue(t, ok)
+	type bug struct{}
+	r = raised(func() {
+		_, _ = ints.Count(func(e int) bool { panic(bug{}) })
+	})
+	isTrue(t, r == bug{})
+}
@@ -0,0 +1,182 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// TypedArray is the type-safe counterpart of Array,
+// lambda expressions are checked by the compiler instead of checkExpress.
+// Map, Sum, Average, Max and Min have type parameters of their own, they are in package typed
+type TypedArray[T any] struct {
+	source []T
+}
+
+// make TypedArray from source([]T type)
+func Typed[T any](source []T) TypedArray[T] {
+	return TypedArray[T]{source}
+}
+
+// make TypedArray from Array
+// panic when the element type of arr is not T
+func FromArray[T any](arr Array) TypedArray[T] {
+	if s, ok := arr.Pointer().([]T); ok {
+		return Typed(s)
+	}
+	v := reflect.Indirect(reflect.ValueOf(arr.Pointer()))
+	s := make([]T, v.Len())
+	for i := range s {
+		t, ok := v.Index(i).Interface().(T)
+		if !ok {
+			panic(fmt.Sprintf("element type[%s] is not %T.", v.Index(i).Type().String(), t))
+		}
+		s[i] = t
+	}
+	return Typed(s)
+}
+
+// convert to reflection based Array
+func (p TypedArray[T]) Array() Array {
+	return LambdaArray(p.Slice())
+}
+
+// the elements of array
+func (p TypedArray[T]) Slice() []T {
+	if p.source == nil {
+		return []T{}
+	}
+	return p.source
+}
+
+func (p TypedArray[T]) Len() int {
+	return len(p.source)
+}
+
+// array filter
+// eg: arr.Filter(func(ele int) bool{ return ele>10})
+func (p TypedArray[T]) Filter(express func(T) bool) TypedArray[T] {
+	ret := make([]T, 0)
+	for _, ele := range p.source {
+		if express(ele) {
+			ret = append(ret, ele)
+		}
+	}
+	return Typed(ret)
+}
+
+// stable sort, express returns true when a must be placed before b
+func (p TypedArray[T]) Sort(express func(a, b T) bool) TypedArray[T] {
+	ret := make([]T, len(p.source))
+	copy(ret, p.source)
+	sort.SliceStable(ret, func(i, j int) bool { return express(ret[i], ret[j]) })
+	return Typed(ret)
+}
+
+// append elements, the source is not modified
+func (p TypedArray[T]) Append(elements ...T) TypedArray[T] {
+	ret := make([]T, 0, len(p.source)+len(elements))
+	ret = append(ret, p.source...)
+	return Typed(append(ret, elements...))
+}
+
+// Determines whether the Array contains any elements
+// express nil returns true when the array is not empty
+func (p TypedArray[T]) Any(express func(T) bool) bool {
+	if express == nil {
+		return p.Len() > 0
+	}
+	for _, ele := range p.source {
+		if express(ele) {
+			return true
+		}
+	}
+	return false
+}
+
+// Determines whether the condition is satisfied for all elements in the Array
+func (p TypedArray[T]) All(express func(T) bool) bool {
+	if p.Len() == 0 {
+		return false
+	}
+	if express == nil {
+		return true
+	}
+	for _, ele := range p.source {
+		if !express(ele) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a number indicating how many elements in the specified Array satisfy the condition
+func (p TypedArray[T]) Count(express func(T) bool) int {
+	if express == nil {
+		return p.Len()
+	}
+	count := 0
+	for _, ele := range p.source {
+		if express(ele) {
+			count++
+		}
+	}
+	return count
+}
+
+func (p TypedArray[T]) find(express func(T) bool, start, step int) (T, error) {
+	var zero T
+	length := p.Len()
+	if length == 0 {
+		return zero, errors.New("empty array")
+	}
+	for i := start; i < length && i >= 0; i += step {
+		if express == nil || express(p.source[i]) {
+			return p.source[i], nil
+		}
+	}
+	return zero, errors.New("not found")
+}
+
+// Returns the first element of an Array that satisfies the condition
+func (p TypedArray[T]) First(express func(T) bool) (T, error) {
+	return p.find(express, 0, 1)
+}
+
+// Returns the last element of an Array that satisfies the condition
+func (p TypedArray[T]) Last(express func(T) bool) (T, error) {
+	return p.find(express, p.Len()-1, -1)
+}
+
+// Returns the element at the zero based index
+func (p TypedArray[T]) Index(i int) (T, error) {
+	if i >= 0 && i < p.Len() {
+		return p.source[i], nil
+	}
+	var zero T
+	return zero, fmt.Errorf("%d out of range", i)
+}
+
+// skip and Returns the elements
+func (p TypedArray[T]) Take(skip, count int) TypedArray[T] {
+	length := p.Len()
+	if skip < 0 {
+		skip = 0
+	}
+	if skip > length {
+		skip = length
+	}
+	end := skip + count
+	if count < 0 || end > length {
+		end = length
+	}
+	ret := make([]T, end-skip)
+	copy(ret, p.source[skip:end])
+	return Typed(ret)
+}
+
+// Determines whether the array contains an element satisfies the express
+func (p TypedArray[T]) Contains(express func(T) bool) bool {
+	return p.Any(express)
+}
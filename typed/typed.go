@@ -0,0 +1,73 @@
+// Package typed holds the operators of lambda.TypedArray with type parameters of their own,
+// Go methods can not have them
+package typed
+
+import (
+	"errors"
+
+	"github.com/favar/lambda"
+)
+
+// Number is the constraint of the values Sum and Average add
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Ordered is the constraint of the keys Max and Min compare
+type Ordered interface {
+	Number | ~string
+}
+
+// map to new array
+// eg: Map(arr, func(u user) string { return u.name })
+func Map[T, R any](arr lambda.TypedArray[T], express func(T) R) lambda.TypedArray[R] {
+	ret := make([]R, arr.Len())
+	for i, ele := range arr.Slice() {
+		ret[i] = express(ele)
+	}
+	return lambda.Typed(ret)
+}
+
+// sum of the values returned by the expression
+func Sum[T any, N Number](arr lambda.TypedArray[T], express func(T) N) N {
+	var sum N
+	for _, ele := range arr.Slice() {
+		sum += express(ele)
+	}
+	return sum
+}
+
+// average of the values returned by the expression
+func Average[T any, N Number](arr lambda.TypedArray[T], express func(T) N) float64 {
+	if arr.Len() == 0 {
+		return float64(0)
+	}
+	return float64(Sum(arr, express)) / float64(arr.Len())
+}
+
+func maxOrMin[T any, K Ordered](arr lambda.TypedArray[T], express func(T) K, isMax bool) (T, error) {
+	var m T
+	if arr.Len() == 0 {
+		return m, errors.New("empty array")
+	}
+	var mk K
+	for i, ele := range arr.Slice() {
+		k := express(ele)
+		if i == 0 || (isMax && k > mk) || (!isMax && k < mk) {
+			m, mk = ele, k
+		}
+	}
+	return m, nil
+}
+
+// maximum of array by the key returned by the expression
+func Max[T any, K Ordered](arr lambda.TypedArray[T], express func(T) K) (T, error) {
+	return maxOrMin(arr, express, true)
+}
+
+// minimum of array by the key returned by the expression
+func Min[T any, K Ordered](arr lambda.TypedArray[T], express func(T) K) (T, error) {
+	return maxOrMin(arr, express, false)
+}
@@ -0,0 +1,51 @@
+package typed
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/favar/lambda"
+)
+
+type user struct {
+	name string
+	age  int
+}
+
+var us = []user{
+	{"Abraham", 20},
+	{"Edith", 25},
+	{"Charles", 40},
+	{"Anthony", 26},
+	{"Abel", 33},
+}
+
+func isTrue(tv interface{}, v bool) {
+	t := tv.(*testing.T)
+	if !v {
+		t.Fail()
+		panic(v)
+	}
+}
+
+func TestMap(t *testing.T) {
+	names := Map(lambda.Typed([]int{1, 2, 3}), func(i int) string { return fmt.Sprintf("un:%d", i) }).Slice()
+	isTrue(t, fmt.Sprint(names) == "[un:1 un:2 un:3]")
+}
+
+func TestSum(t *testing.T) {
+	isTrue(t, Sum(lambda.Typed(us), func(u user) int { return u.age }) == 144)
+	isTrue(t, Average(lambda.Typed(us), func(u user) int { return u.age }) == 28.8)
+	isTrue(t, Average(lambda.Typed([]user{}), func(u user) int { return u.age }) == 0)
+}
+
+func TestMax(t *testing.T) {
+	eldest, err := Max(lambda.Typed(us), func(u user) int { return u.age })
+	isTrue(t, err == nil && eldest.name == "Charles")
+	youngest, err := Min(lambda.Typed(us), func(u user) int { return u.age })
+	isTrue(t, err == nil && youngest.name == "Abraham")
+	last, err := Max(lambda.Typed(us), func(u user) string { return u.name })
+	isTrue(t, err == nil && last.name == "Edith")
+	_, err = Max(lambda.Typed([]user{}), func(u user) int { return u.age })
+	isTrue(t, err != nil)
+}
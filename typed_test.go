@@ -0,0 +1,54 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTypedArray_Filter(t *testing.T) {
+	defer report(t, time.Now())
+	ret := Typed(makeIntArray()).Filter(func(ele int) bool { return ele%3 == 0 }).Slice()
+	isTrue(t, len(ret) == count/3)
+
+	us := makeUsers()
+	ret1 := Typed(us).Filter(func(u user) bool { return u.age < 30 }).Slice()
+	isTrue(t, len(ret1) == 3)
+	fmt.Println(ret1)
+}
+
+func TestTypedArray_Sort(t *testing.T) {
+	defer report(t, time.Now())
+	arr := []int{1, 3, 8, 6, 12, 5, 9}
+	ret := Typed(arr).Sort(func(a, b int) bool { return a < b }).Slice()
+	isTrue(t, fmt.Sprint(ret) == "[1 3 5 6 8 9 12]")
+	isTrue(t, arr[1] == 3)
+}
+
+func TestTypedArray_First(t *testing.T) {
+	defer report(t, time.Now())
+	want := Typed([]int{1, 5, 6, 3, 8, 9, 3, 12, 56, 186, 4, 9, 14})
+	c, err := want.First(func(e int) bool { return e > 30 })
+	isTrue(t, err == nil && c == 56)
+	c, err = want.Last(func(e int) bool { return e > 30 })
+	isTrue(t, err == nil && c == 186)
+	_, err = want.First(func(e int) bool { return e > 1000 })
+	isTrue(t, err != nil)
+}
+
+func TestTypedArray_Take(t *testing.T) {
+	defer report(t, time.Now())
+	ret := Typed(makeIntArray()).Take(200, 10).Slice()
+	isTrue(t, len(ret) == 10 && ret[0] == 201 && ret[9] == 210)
+	isTrue(t, Typed([]int{1, 2, 3}).Take(10, 10).Len() == 0)
+}
+
+func TestFromArray(t *testing.T) {
+	defer report(t, time.Now())
+	arr := LambdaArray([]int{1, 2, 3, 4, 5}).Filter(func(e int) bool { return e > 2 })
+	ret := FromArray[int](arr).Slice()
+	isTrue(t, fmt.Sprint(ret) == "[3 4 5]")
+
+	back := Typed(ret).Array().Sum(nil).(int)
+	isTrue(t, back == 12)
+}
@@ -0,0 +1,80 @@
+package lambda
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// zip the elements at the same index of arrays by express
+// longest pads the shorter arrays with defaults, otherwise stops at the shortest array
+// arg is the position of express in the arguments of the operator
+func zip(arrays []*_array, express interface{}, arg int, longest bool, defaults []interface{}) Array {
+	in := make([]reflect.Type, len(arrays))
+	for i, arr := range arrays {
+		in[i] = arr.elementType
+	}
+	var ot reflect.Type
+	argument(arg, func() { ot = checkExpressRARTO(express, in) })
+	fn := reflect.ValueOf(express)
+
+	pads := make([]reflect.Value, len(arrays))
+	length := -1
+	for i, arr := range arrays {
+		pads[i] = reflect.Zero(arr.elementType)
+		if i < len(defaults) && defaults[i] != nil {
+			if t := reflect.TypeOf(defaults[i]); !t.AssignableTo(arr.elementType) {
+				panic(fmt.Sprintf("default type[%s] is not %s.", t.String(), arr.elementType.String()))
+			}
+			pads[i] = reflect.ValueOf(defaults[i])
+		}
+		if n := arr.Len(); length < 0 || (longest && n > length) || (!longest && n < length) {
+			length = n
+		}
+	}
+	if length < 0 {
+		length = 0
+	}
+
+	ret := reflect.MakeSlice(reflect.SliceOf(ot), length, length)
+	params := make([]reflect.Value, len(arrays))
+	for i := 0; i < length; i++ {
+		for j, arr := range arrays {
+			if i < arr.Len() {
+				params[j] = arr.value.Index(i)
+			} else {
+				params[j] = pads[j]
+			}
+		}
+		ret.Index(i).Set(fn.Call(params)[0])
+	}
+	return innerLambdaArray(ret)
+}
+
+func (p *_array) Zip(other Array, express interface{}) Array {
+	return zip([]*_array{p, asInner(other)}, express, 2, false, nil)
+}
+
+func (p *_array) ZipLongest(other Array, express, defaultA, defaultB interface{}) Array {
+	return zip([]*_array{p, asInner(other)}, express, 2, true, []interface{}{defaultA, defaultB})
+}
+
+// zip the elements at the same index of three or more arrays, stops at the shortest array
+// express func(a T1, b T2, c T3...) R
+// eg: ZipN(func(t time.Time, v float64, ok bool) point { return point{t, v, ok} }, times, values, flags)
+func ZipN(express interface{}, arrays ...Array) Array {
+	inner := make([]*_array, len(arrays))
+	for i, arr := range arrays {
+		inner[i] = asInner(arr)
+	}
+	return zip(inner, express, 1, false, nil)
+}
+
+// zip the elements at the same index of three or more arrays, pads the shorter arrays
+// defaults are the paddings of each array, nil or missing pads with the zero value
+func ZipLongestN(express interface{}, defaults []interface{}, arrays ...Array) Array {
+	inner := make([]*_array, len(arrays))
+	for i, arr := range arrays {
+		inner[i] = asInner(arr)
+	}
+	return zip(inner, express, 1, true, defaults)
+}
@@ -0,0 +1,43 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test__array_Zip(t *testing.T) {
+	defer report(t, time.Now())
+	times := LambdaArray([]int64{100, 200, 300})
+	values := LambdaArray([]float64{1.5, 2.5})
+	ret := times.Zip(values, func(ts int64, v float64) string { return fmt.Sprint(ts, ":", v) }).Pointer().([]string)
+	isTrue(t, fmt.Sprint(ret) == "[100:1.5 200:2.5]")
+
+	longest := times.ZipLongest(values, func(ts int64, v float64) float64 { return v }, nil, -1.0).Pointer().([]float64)
+	isTrue(t, fmt.Sprint(longest) == "[1.5 2.5 -1]")
+
+	zero := values.ZipLongest(times, func(v float64, ts int64) float64 { return v }, nil, nil).Pointer().([]float64)
+	isTrue(t, fmt.Sprint(zero) == "[1.5 2.5 0]")
+
+	// a concrete default of an interface element type
+	anys := LambdaArray([]interface{}{"a"})
+	padded := times.ZipLongest(anys, func(ts int64, v interface{}) string { return fmt.Sprint(v) }, nil, "-").Pointer()
+	isTrue(t, fmt.Sprint(padded) == "[a - -]")
+
+	sum := LambdaArray(makeIntArray()).Zip(LambdaArray(makeIntArray()), func(a, b int) int { return a + b }).Sum(nil)
+	isTrue(t, sum == count*(count+1))
+}
+
+func TestZipN(t *testing.T) {
+	defer report(t, time.Now())
+	names := LambdaArray([]string{"Abraham", "Edith", "Charles"})
+	ages := LambdaArray([]int{20, 25, 40, 26})
+	flags := LambdaArray([]bool{true, false, true})
+	ret := ZipN(func(name string, age int, ok bool) user { return user{name, age} }, names, ages, flags).
+		Filter(func(u user) bool { return u.age > 20 }).Pointer().([]user)
+	isTrue(t, fmt.Sprint(ret) == "[{Edith 25} {Charles 40}]")
+
+	longest := ZipLongestN(func(name string, age int, ok bool) string { return fmt.Sprint(name, age, ok) },
+		[]interface{}{"?"}, names, ages, flags).Pointer().([]string)
+	isTrue(t, longest[3] == "?26 false")
+}